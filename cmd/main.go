@@ -2,35 +2,192 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
 	"poke-repl/cmd/repl"
+	"poke-repl/internal/api/pokeapi"
+	"poke-repl/internal/cache"
 	"poke-repl/internal/config"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 const (
 	cliName = "Pokedex"
 )
 
+// inFlight tracks the context of the command being run, so an interrupt
+// cancels that command instead of ending the session.
+type inFlight struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+}
+
+func (f *inFlight) start(timeout time.Duration) context.Context {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if timeout > 0 {
+		ctx, cancel = context.WithTimeout(context.Background(), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(context.Background())
+	}
+	f.mu.Lock()
+	f.cancel = cancel
+	f.mu.Unlock()
+	return ctx
+}
+
+func (f *inFlight) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cancel != nil {
+		f.cancel()
+		f.cancel = nil
+	}
+}
+
+// interrupt cancels the running command and reports whether there was one.
+func (f *inFlight) interrupt() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.cancel == nil {
+		return false
+	}
+	f.cancel()
+	return true
+}
+
+func newFileStorage(dir string) (*cache.FileStorage, error) {
+	if dir == "" {
+		var err error
+		dir, err = cache.DefaultDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cache.NewFileStorage(dir)
+}
+
 func main() {
-	cfg := &config.Config{}
+	baseURL := flag.String("base-url", pokeapi.DefaultBaseURL, "PokeAPI base URL")
+	userAgent := flag.String("user-agent", pokeapi.DefaultUserAgent, "User-Agent sent with every request")
+	timeout := flag.Duration("timeout", pokeapi.DefaultTimeout, "timeout of a single HTTP request attempt")
+	cacheInterval := flag.Duration("cache-interval", pokeapi.DefaultCacheInterval, "how often expired responses are removed from the cache")
+	cacheTTL := flag.Duration("cache-ttl", 7*24*time.Hour, "how long responses stay cached")
+	cacheHardTTL := flag.Duration("cache-hard-ttl", 30*24*time.Hour, "how long expired responses may still be served while they refresh in the background")
+	cacheMaxBytes := flag.Int64("cache-max-bytes", pokeapi.DefaultCacheMaxBytes, "size budget of the cache in bytes, 0 means unbounded")
+	diskCache := flag.Bool("disk-cache", true, "keep cached responses on disk across restarts")
+	cacheDir := flag.String("cache-dir", "", "directory for the disk cache, defaults to the user cache directory")
+	retries := flag.Int("retries", pokeapi.DefaultRetryPolicy.MaxAttempts, "attempts per request on 429 and 5xx responses, 1 disables retries")
+	rateLimit := flag.Float64("rate", pokeapi.DefaultRateLimit, "maximum requests per second to PokeAPI, 0 disables the limiter")
+	burst := flag.Int("burst", pokeapi.DefaultBurst, "requests allowed in a burst above the rate limit")
+	offline := flag.Bool("offline", false, "serve only cached data and never touch the network")
+	prewarm := flag.Bool("prewarm", false, "fill the cache with every location area and pokemon, then exit")
+	prewarmConcurrency := flag.Int("prewarm-concurrency", pokeapi.DefaultPrewarmConcurrency, "concurrent requests used by -prewarm")
+	language := flag.String("language", pokeapi.DefaultLanguage, "language of Pokédex entries and other localized text")
+	gameVersion := flag.String("game-version", "", "game version of Pokédex entries, such as red, defaults to the most recent game")
+	commandTimeout := flag.Duration("command-timeout", 30*time.Second, "deadline for a single command, 0 disables it")
+	flag.Parse()
+
+	retryPolicy := pokeapi.DefaultRetryPolicy
+	retryPolicy.MaxAttempts = *retries
+	var limiter *pokeapi.RateLimiter
+	if *rateLimit > 0 {
+		limiter = pokeapi.NewRateLimiter(*rateLimit, *burst)
+	}
 
+	cacheOpts := []cache.Option{
+		cache.WithTTL(*cacheTTL),
+		cache.WithHardTTL(*cacheHardTTL),
+		cache.WithMaxBytes(*cacheMaxBytes),
+	}
+	if *diskCache {
+		storage, err := newFileStorage(*cacheDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "disk cache disabled: %v\n", err)
+		} else {
+			cacheOpts = append(cacheOpts, cache.WithStorage(storage))
+		}
+	}
+
+	pokeCache := cache.NewCache(*cacheInterval, cacheOpts...)
+	defer pokeCache.Close()
+	client := pokeapi.NewClient(
+		pokeapi.WithBaseURL(*baseURL),
+		pokeapi.WithUserAgent(*userAgent),
+		pokeapi.WithTimeout(*timeout),
+		pokeapi.WithRetryPolicy(retryPolicy),
+		pokeapi.WithRateLimiter(limiter),
+		pokeapi.WithCache(pokeCache),
+		pokeapi.WithOffline(*offline),
+	)
+	defer client.Close()
+	repl.UseClient(client)
+
+	cfg := &config.Config{Language: *language, Version: *gameVersion}
+	if *prewarm {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		cmd, err := repl.LookupCommand("prewarm")
+		if err == nil {
+			err = cmd.Callback(ctx, cfg, []string{strconv.Itoa(*prewarmConcurrency)})
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			client.Close()
+			pokeCache.Close()
+			os.Exit(1)
+		}
+		return
+	}
+
+	running := &inFlight{}
+	interrupts := make(chan os.Signal, 1)
+	signal.Notify(interrupts, os.Interrupt)
+	go func() {
+		for range interrupts {
+			if !running.interrupt() {
+				fmt.Printf("\n%s > ", cliName)
+			}
+		}
+	}()
+
+	scanner := bufio.NewScanner(os.Stdin)
 	for {
-		scanner := bufio.NewScanner(os.Stdin)
 		fmt.Printf("%s > ", cliName)
-		if scanner.Scan() {
-			command := scanner.Text()
-			commandArgs := strings.Split(command, " ")
-			cmd, err := repl.LookupCommand(commandArgs[0])
-			if err != nil {
-				fmt.Println(err)
-				continue
-			}
-			err = cmd.Callback(cfg, commandArgs[1:])
-			if err != nil {
-				fmt.Println(err)
-			}
+		if !scanner.Scan() {
+			fmt.Println()
+			return
+		}
+		command := scanner.Text()
+		commandArgs := strings.Split(command, " ")
+		cmd, err := repl.LookupCommand(commandArgs[0])
+		if err != nil {
+			fmt.Println(err)
+			continue
+		}
+		deadline := *commandTimeout
+		if cmd.LongRunning() {
+			deadline = 0
+		}
+		ctx := running.start(deadline)
+		err = cmd.Callback(ctx, cfg, commandArgs[1:])
+		running.stop()
+		switch {
+		case errors.Is(err, repl.ErrExit):
+			return
+		case errors.Is(err, context.Canceled):
+			fmt.Println("command canceled")
+		case errors.Is(err, context.DeadlineExceeded):
+			fmt.Println("command timed out")
+		case err != nil:
+			fmt.Println(err)
 		}
 	}
 }
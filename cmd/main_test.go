@@ -0,0 +1,48 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlightInterruptWhenIdle(t *testing.T) {
+	running := &inFlight{}
+	assert.False(t, running.interrupt())
+
+	ctx := running.start(0)
+	running.stop()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	assert.False(t, running.interrupt())
+}
+
+func TestInFlightInterruptCancelsCommand(t *testing.T) {
+	running := &inFlight{}
+	ctx := running.start(0)
+	_, hasDeadline := ctx.Deadline()
+	assert.False(t, hasDeadline)
+
+	canceled := make(chan error, 1)
+	go func() {
+		<-ctx.Done()
+		canceled <- ctx.Err()
+	}()
+	assert.True(t, running.interrupt())
+	select {
+	case err := <-canceled:
+		assert.ErrorIs(t, err, context.Canceled)
+	case <-time.After(time.Second):
+		t.Fatal("expected the running command to be canceled")
+	}
+	running.stop()
+}
+
+func TestInFlightTimeout(t *testing.T) {
+	running := &inFlight{}
+	ctx := running.start(time.Millisecond)
+	defer running.stop()
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
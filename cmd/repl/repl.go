@@ -1,23 +1,70 @@
 package repl
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"os"
 	"os/exec"
 	"poke-repl/internal/api/pokeapi"
+	"poke-repl/internal/cache"
 	"poke-repl/internal/config"
+	"poke-repl/internal/fuzzy"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
 )
 
 type cliCommand struct {
 	name        string
 	description string
-	url         string
-	Callback    func(cfg *config.Config, args []string) error
+	longRunning bool
+	Callback    func(ctx context.Context, cfg *config.Config, args []string) error
 }
 
+// LongRunning reports whether the command may outlive the per-command
+// deadline, it can still be interrupted.
+func (c cliCommand) LongRunning() bool {
+	return c.longRunning
+}
+
+// ErrExit is returned by the exit command, the caller should release its
+// resources and end the session.
+var ErrExit = errors.New("exit")
+
 var pokeDex = pokeapi.NewPokedex()
 
+var (
+	clientMu   sync.Mutex
+	pokeClient *pokeapi.Client
+)
+
+// UseClient sets the client commands talk to, the caller keeps ownership and
+// closes it.
+func UseClient(client *pokeapi.Client) {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	pokeClient = client
+}
+
+// client returns the client set with UseClient. A default client is only
+// created on first use, so importing the package starts no cache reaper.
+func client() *pokeapi.Client {
+	clientMu.Lock()
+	defer clientMu.Unlock()
+	if pokeClient == nil {
+		pokeClient = pokeapi.NewClient()
+	}
+	return pokeClient
+}
+
 func CommandsMap() map[string]cliCommand {
 	return map[string]cliCommand{
 		"help": {
@@ -47,35 +94,115 @@ func CommandsMap() map[string]cliCommand {
 		},
 		"map": {
 			name:        "map",
-			description: "Show locations in the pokemon world",
-			url:         "https://pokeapi.co/api/v2/location-area?offset=0&limit=20",
+			description: "Show locations in the pokemon world, or every area of a region (map [region])",
+			longRunning: true,
 			Callback:    mapCommand,
 		},
+		"regions": {
+			name:        "regions",
+			description: "List the regions of the pokemon world",
+			Callback:    regionsCommand,
+		},
+		"region": {
+			name:        "region",
+			description: "Show the locations of a region (region <name>)",
+			Callback:    regionCommand,
+		},
+		"location": {
+			name:        "location",
+			description: "Show the areas of a location (location <name>)",
+			Callback:    locationCommand,
+		},
 		"explore": {
 			name:        "explore",
-			description: "Exlore the pokemon world area by area",
-			url:         "https://pokeapi.co/api/v2/location-area/",
+			description: "Exlore the pokemon world area by area (explore <area> [--details] [--version red])",
 			Callback:    exploreCommand,
 		},
 		"catch": {
 			name:        "catch",
 			description: "Catch a pokemon",
-			url:         "https://pokeapi.co/api/v2/pokemon/",
 			Callback:    catchCommand,
 		},
 		"inspect": {
 			name:        "inspect",
-			description: "Inspect a pokemon in your pokedex",
+			description: "Inspect a pokemon in your pokedex (inspect <pokemon> [--lang en] [--version red])",
 			Callback:    inspectCommand,
 		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "Show how a pokemon evolves (evolutions <pokemon>)",
+			Callback:    evolutionsCommand,
+		},
+		"type": {
+			name:        "type",
+			description: "Show the damage relations of a type (type <name>)",
+			Callback:    typeCommand,
+		},
+		"weakness": {
+			name:        "weakness",
+			description: "Show how effective every type is against a pokemon (weakness <pokemon>)",
+			Callback:    weaknessCommand,
+		},
+		"moves": {
+			name:        "moves",
+			description: "Show the moves a pokemon learns (moves <pokemon> [--version-group red-blue] [--method level-up|machine|egg|tutor] [--lang en])",
+			longRunning: true,
+			Callback:    movesCommand,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Show what an ability does and which pokemon have it (ability <name> [--lang en])",
+			Callback:    abilityCommand,
+		},
+		"item": {
+			name:        "item",
+			description: "Show an item (item <name> [--lang en])",
+			Callback:    itemCommand,
+		},
+		"berry": {
+			name:        "berry",
+			description: "Show a berry (berry <name> [--lang en])",
+			Callback:    berryCommand,
+		},
 		"pokedex": {
 			name:        "pokedex",
 			description: "Show pokemon in your pokedex",
 			Callback:    pokedexCommand,
 		},
+		"cache": {
+			name:        "cache",
+			description: "Inspect the cache (cache stats|ls [prefix]|show <key>|purge [prefix]|ttl [duration])",
+			Callback:    cacheCommand,
+		},
+		"export": {
+			name:        "export",
+			description: "Export the cache to a bundle file (export <file>)",
+			Callback:    exportCommand,
+		},
+		"import": {
+			name:        "import",
+			description: "Import a cache bundle file (import <file> [newer|keep|overwrite])",
+			Callback:    importCommand,
+		},
+		"offline": {
+			name:        "offline",
+			description: "Toggle offline mode (offline [on|off]) or list cached resources (offline ls [prefix])",
+			Callback:    offlineCommand,
+		},
+		"prewarm": {
+			name:        "prewarm",
+			description: "Download every location area and pokemon into the cache (prewarm [concurrency])",
+			longRunning: true,
+			Callback:    prewarmCommand,
+		},
+		"ratelimit": {
+			name:        "ratelimit",
+			description: "Show the state of the PokeAPI rate limiter",
+			Callback:    rateLimitCommand,
+		},
 	}
 }
-func commandHelp(cfg *config.Config, args []string) error {
+func commandHelp(ctx context.Context, cfg *config.Config, args []string) error {
 	commands := CommandsMap()
 	fmt.Println("Available commands:")
 	for name, command := range commands {
@@ -85,19 +212,23 @@ func commandHelp(cfg *config.Config, args []string) error {
 	return nil
 }
 
-func commandExit(cfg *config.Config, args []string) error {
+func commandExit(ctx context.Context, cfg *config.Config, args []string) error {
 	fmt.Println("Bye!")
-	os.Exit(1)
-	return nil
+	return ErrExit
 }
 func LookupCommand(name string) (cliCommand, error) {
-	command, ok := CommandsMap()[name]
+	commands := CommandsMap()
+	command, ok := commands[name]
 	if !ok {
-		return cliCommand{}, fmt.Errorf("command not found")
+		names := make([]string, 0, len(commands))
+		for name := range commands {
+			names = append(names, name)
+		}
+		return cliCommand{}, fmt.Errorf("command not found%s", didYouMean(fuzzy.Closest(name, names, maxSuggestions)))
 	}
 	return command, nil
 }
-func clearScreen(cfg *config.Config, args []string) error {
+func clearScreen(ctx context.Context, cfg *config.Config, args []string) error {
 	cmd := exec.Command("clear")
 	cmd.Stdout = os.Stdout
 	cmd.Run()
@@ -105,55 +236,124 @@ func clearScreen(cfg *config.Config, args []string) error {
 	return nil
 }
 
-func nextPage(cfg *config.Config, args []string) error {
+func nextPage(ctx context.Context, cfg *config.Config, args []string) error {
 	if cfg.NextUrl == "" {
 		return fmt.Errorf("no next page available")
 	}
 	switch cfg.Cmd {
 	case "map":
 		cfg.Referrer = "next"
-		return mapCommand(cfg, args)
+		return mapCommand(ctx, cfg, args)
 
 	}
 	return nil
 }
-func previousPage(cfg *config.Config, args []string) error {
+func previousPage(ctx context.Context, cfg *config.Config, args []string) error {
 	if cfg.PreviousUrl == "" {
 		return fmt.Errorf("no previous page available")
 	}
 	switch cfg.Cmd {
 	case "map":
 		cfg.Referrer = "previous"
-		return mapCommand(cfg, args)
+		return mapCommand(ctx, cfg, args)
 
 	}
 	return nil
 }
 
-func mapCommand(cfg *config.Config, args []string) error {
+func mapCommand(ctx context.Context, cfg *config.Config, args []string) error {
 	cfg.Cmd = "map"
-	cmd, err := LookupCommand("map")
-	if err != nil {
-		return err
+	if len(args) > 1 {
+		return fmt.Errorf("only one region can be mapped at a time")
 	}
-	defaultUrl := cmd.url
+	if len(args) == 1 {
+		return mapRegion(ctx, cfg, args[0])
+	}
+	defaultUrl := ""
 	if cfg.NextUrl != "" && cfg.Referrer == "next" {
 		defaultUrl = cfg.NextUrl
 	}
 	if cfg.PreviousUrl != "" && cfg.Referrer == "previous" {
 		defaultUrl = cfg.PreviousUrl
 	}
-	locations, err := pokeapi.Location.GetLocation(defaultUrl, cfg)
+	locations, err := client().GetLocation(ctx, defaultUrl, cfg)
 	for _, location := range locations {
 		fmt.Printf("- %s\n", location.Name)
 	}
 	if err != nil {
-		return err
+		return friendlyError(ctx, err, "", "")
 	}
 	return nil
 }
 
-func exploreCommand(cfg *config.Config, args []string) error {
+// mapRegion lists every area of a region at once, the pages of the whole
+// world are left behind so next and previous have nothing to go to.
+func mapRegion(ctx context.Context, cfg *config.Config, region string) error {
+	areas, err := client().RegionAreas(ctx, region)
+	if err != nil {
+		return friendlyError(ctx, err, "region", region)
+	}
+	cfg.NextUrl, cfg.PreviousUrl = "", ""
+	for _, area := range areas {
+		fmt.Printf("- %s\n", area)
+	}
+	return nil
+}
+
+func regionsCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("no arguments expected")
+	}
+	regions, err := client().Names(ctx, "region")
+	if err != nil {
+		return friendlyError(ctx, err, "", "")
+	}
+	for _, region := range regions {
+		fmt.Printf("- %s\n", region)
+	}
+	return nil
+}
+
+func regionCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single region")
+	}
+	region, err := client().Region(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "region", args[0])
+	}
+	fmt.Printf("Region: %s\n", region.Name)
+	fmt.Printf("Generation: %s\n", region.MainGeneration.Name)
+	fmt.Println("Locations:")
+	for _, location := range region.Locations {
+		fmt.Printf("  - %s\n", location.Name)
+	}
+	return nil
+}
+
+func locationCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single location")
+	}
+	location, err := client().Location(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "location", args[0])
+	}
+	fmt.Printf("Location: %s\n", location.Name)
+	fmt.Printf("Region: %s\n", location.Region.Name)
+	fmt.Println("Areas:")
+	for _, area := range location.Areas {
+		fmt.Printf("  - %s\n", area.Name)
+	}
+	return nil
+}
+
+func exploreCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	args, details := takeSwitch(args, "details")
+	args, options, err := parseOptions(args, "version")
+	if err != nil {
+		return err
+	}
 	if len(args) == 0 {
 		return fmt.Errorf("no area specified")
 	}
@@ -161,13 +361,15 @@ func exploreCommand(cfg *config.Config, args []string) error {
 		return fmt.Errorf("only one area can be explored at a time")
 	}
 	cfg.Cmd = "explore"
-	cmd, err := LookupCommand("explore")
-	if err != nil {
-		return err
+	if version, ok := options["version"]; details || ok {
+		if !ok {
+			version = cfg.Version
+		}
+		return exploreDetails(ctx, args[0], version)
 	}
-	pokemonList, err := pokeapi.Explorer.Explore(cmd.url, args[0], cfg)
+	pokemonList, err := client().Explore(ctx, args[0])
 	if err != nil {
-		return err
+		return friendlyError(ctx, err, "area", args[0])
 	}
 	for _, pokemon := range pokemonList {
 		fmt.Printf("- %s\n", pokemon)
@@ -175,19 +377,60 @@ func exploreCommand(cfg *config.Config, args []string) error {
 	return nil
 }
 
-func catchCommand(cfg *config.Config, args []string) error {
-	if len(args) > 1 {
-		return fmt.Errorf("only one pokemon can be caught at a time")
+// exploreDetails prints how every pokemon of the area is encountered in a
+// game version, the first version the area has encounters in by default.
+func exploreDetails(ctx context.Context, name, version string) error {
+	area, err := client().LocationArea(ctx, name)
+	if err != nil {
+		return friendlyError(ctx, err, "area", name)
+	}
+	versions := area.Versions()
+	if version == "" && len(versions) > 0 {
+		version = versions[0]
+	}
+	encounters := area.Encounters(version)
+	if len(encounters) == 0 {
+		if len(versions) == 0 {
+			fmt.Printf("No encounters in %s\n", area.Name)
+		} else {
+			fmt.Printf("No encounters in %s for %s, try one of %s\n", area.Name, version, strings.Join(versions, ", "))
+		}
+		return nil
 	}
-	cfg.Cmd = "catch"
-	cmd, err := LookupCommand("catch")
 
-	if err != nil {
+	fmt.Printf("Encounters in %s (%s):\n", area.Name, version)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Pokemon\tMethod\tLevels\tChance")
+	for _, encounter := range encounters {
+		levels := strconv.Itoa(encounter.MinLevel)
+		if encounter.MaxLevel != encounter.MinLevel {
+			levels = fmt.Sprintf("%d-%d", encounter.MinLevel, encounter.MaxLevel)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%d%%\n", encounter.Pokemon, encounter.Method, levels, encounter.Chance)
+	}
+	if err := w.Flush(); err != nil {
 		return err
 	}
-	pokemon, err := pokeapi.Catch.CatchPokemon(cmd.url, args[0], cfg)
+	if rates := area.EncounterRates(version); len(rates) > 0 {
+		fmt.Println("Encounter rates:")
+		for _, rate := range rates {
+			fmt.Printf("  - %s: %d\n", rate.Method, rate.Rate)
+		}
+	}
+	return nil
+}
+
+func catchCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no pokemon specified")
+	}
+	if len(args) > 1 {
+		return fmt.Errorf("only one pokemon can be caught at a time")
+	}
+	cfg.Cmd = "catch"
+	pokemon, err := client().CatchPokemon(ctx, args[0])
 	if err != nil {
-		return err
+		return friendlyError(ctx, err, "Pokémon", args[0])
 	}
 	res := rand.Intn(pokemon.BaseExperience)
 	fmt.Printf("Throwing a Pokeball at %s...\n", pokemon.Name)
@@ -200,7 +443,60 @@ func catchCommand(cfg *config.Config, args []string) error {
 	return nil
 }
 
-func inspectCommand(cfg *config.Config, args []string) error {
+// maxSuggestions caps how many names a "did you mean" hint offers.
+const maxSuggestions = 3
+
+// friendlyError rewords the typed errors of the pokeapi package for the
+// prompt, noun and name describe what was looked up and may be empty when
+// there is no name to report. Names that are not found come with the closest
+// known names of the same resource. Other errors, such as cancellations, are
+// returned unchanged.
+func friendlyError(ctx context.Context, err error, noun, name string) error {
+	var notFound *pokeapi.NotFoundError
+	var decodeErr *pokeapi.DecodeError
+	switch {
+	case errors.As(err, &notFound) && noun != "":
+		return fmt.Errorf("no %s named %s%s", noun, name, suggestNames(ctx, notFound.Resource, name))
+	case errors.Is(err, pokeapi.ErrRateLimited):
+		return fmt.Errorf("PokeAPI is rate limiting requests, try again in a moment")
+	case errors.Is(err, pokeapi.ErrUpstream):
+		return fmt.Errorf("PokeAPI is unavailable right now, try again later (%w)", err)
+	case errors.As(err, &decodeErr):
+		return fmt.Errorf("PokeAPI sent an unreadable %s (%w)", decodeErr.Resource, err)
+	}
+	return err
+}
+
+// suggestNames looks name up in the name index of resource, the index is
+// cached after the first miss. Suggestions are best effort, an index that
+// can't be fetched, such as in offline mode, yields none.
+func suggestNames(ctx context.Context, resource, name string) string {
+	if resource == "" {
+		return ""
+	}
+	names, err := client().Names(ctx, resource)
+	if err != nil {
+		return ""
+	}
+	return didYouMean(fuzzy.Closest(name, names, maxSuggestions))
+}
+
+func didYouMean(suggestions []string) string {
+	switch len(suggestions) {
+	case 0:
+		return ""
+	case 1:
+		return fmt.Sprintf(", did you mean %s?", suggestions[0])
+	}
+	last := len(suggestions) - 1
+	return fmt.Sprintf(", did you mean %s or %s?", strings.Join(suggestions[:last], ", "), suggestions[last])
+}
+
+func inspectCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	args, options, err := parseOptions(args, "lang", "version")
+	if err != nil {
+		return err
+	}
 	if len(args) == 0 {
 		return fmt.Errorf("no pokemon specified")
 	}
@@ -208,7 +504,28 @@ func inspectCommand(cfg *config.Config, args []string) error {
 	if !ok {
 		return fmt.Errorf("you haven't caught %s yet", args[0])
 	}
+	language := chosenLanguage(cfg, options)
+	version := cfg.Version
+	if v, ok := options["version"]; ok {
+		version = v
+	}
+	speciesName := pokemon.Species.Name
+	if speciesName == "" {
+		speciesName = pokemon.Name
+	}
+	// The species only adds to what was saved when the pokemon was caught,
+	// inspecting still works when it can't be fetched.
+	species, err := client().Species(ctx, speciesName)
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
 	fmt.Printf("Name: %s\n", pokemon.Name)
+	if err == nil {
+		if genus := species.Genus(language); genus != "" {
+			fmt.Printf("Genus: %s\n", genus)
+		}
+	}
 	fmt.Printf("Height: %d\n", pokemon.Height)
 	fmt.Printf("Weight: %d\n", pokemon.Weight)
 	fmt.Println("Stats:")
@@ -219,10 +536,379 @@ func inspectCommand(cfg *config.Config, args []string) error {
 	for _, typeName := range pokemon.Types {
 		fmt.Printf("  - %s\n", typeName.Type.Name)
 	}
+	fmt.Println("Abilities:")
+	abilities := slices.Clone(pokemon.Abilities)
+	sort.SliceStable(abilities, func(i, j int) bool {
+		return abilities[i].Slot < abilities[j].Slot
+	})
+	for _, ability := range abilities {
+		if ability.IsHidden {
+			fmt.Printf("  - %s (hidden)\n", ability.Ability.Name)
+		} else {
+			fmt.Printf("  - %s\n", ability.Ability.Name)
+		}
+	}
+	if err == nil {
+		if text, from, ok := species.FlavorText(language, version); ok {
+			fmt.Printf("Pokédex entry (%s): %s\n", from, text)
+		}
+	}
+	return nil
+}
+
+func evolutionsCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single pokemon")
+	}
+	pokemon, err := client().CatchPokemon(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "Pokémon", args[0])
+	}
+	speciesName := pokemon.Species.Name
+	if speciesName == "" {
+		speciesName = pokemon.Name
+	}
+	species, err := client().Species(ctx, speciesName)
+	if err != nil {
+		return friendlyError(ctx, err, "species", speciesName)
+	}
+	id, ok := species.EvolutionChainID()
+	if !ok {
+		fmt.Printf("%s does not evolve\n", pokemon.Name)
+		return nil
+	}
+	chain, err := client().EvolutionChain(ctx, id)
+	if err != nil {
+		return friendlyError(ctx, err, "", "")
+	}
+	fmt.Println(chain.Chain.Species.Name)
+	printEvolutions(chain.Chain.EvolvesTo, "")
 	return nil
 }
 
-func pokedexCommand(cfg *config.Config, args []string) error {
+// printEvolutions draws links as the branches of a tree below their parent,
+// prefix carries the branches of the enclosing levels.
+func printEvolutions(links []pokeapi.ChainLink, prefix string) {
+	for i, link := range links {
+		branch, indent := "|-- ", "|   "
+		if i == len(links)-1 {
+			branch, indent = "`-- ", "    "
+		}
+		var details []string
+		for _, detail := range link.EvolutionDetails {
+			if s := detail.String(); s != "" {
+				details = append(details, s)
+			}
+		}
+		line := prefix + branch + link.Species.Name
+		if len(details) > 0 {
+			line += " (" + strings.Join(details, " or ") + ")"
+		}
+		fmt.Println(line)
+		printEvolutions(link.EvolvesTo, prefix+indent)
+	}
+}
+
+func typeCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single type")
+	}
+	t, err := client().Type(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "type", args[0])
+	}
+	relations := t.DamageRelations
+	fmt.Printf("Type: %s\n", t.Name)
+	fmt.Println("Attacking:")
+	printTypeGroup("2x", relations.DoubleDamageTo)
+	printTypeGroup("½x", relations.HalfDamageTo)
+	printTypeGroup("0x", relations.NoDamageTo)
+	fmt.Println("Defending:")
+	printTypeGroup("2x", relations.DoubleDamageFrom)
+	printTypeGroup("½x", relations.HalfDamageFrom)
+	printTypeGroup("0x", relations.NoDamageFrom)
+	return nil
+}
+
+func printTypeGroup(label string, types []pokeapi.NamedResource) {
+	if len(types) == 0 {
+		return
+	}
+	names := make([]string, 0, len(types))
+	for _, t := range types {
+		names = append(names, t.Name)
+	}
+	fmt.Printf("  %s: %s\n", label, strings.Join(names, ", "))
+}
+
+// weaknessGroups are the multipliers weaknessCommand reports, regular damage
+// is left out.
+var weaknessGroups = []struct {
+	multiplier float64
+	label      string
+}{
+	{4, "4x"},
+	{2, "2x"},
+	{0.5, "½x"},
+	{0.25, "¼x"},
+	{0, "immune"},
+}
+
+func weaknessCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single pokemon")
+	}
+	pokemon, err := client().CatchPokemon(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "Pokémon", args[0])
+	}
+	chart, err := client().TypeChart(ctx)
+	if err != nil {
+		return friendlyError(ctx, err, "", "")
+	}
+	var types []string
+	for _, t := range pokemon.Types {
+		types = append(types, t.Type.Name)
+	}
+	defense := chart.Defense(types...)
+	fmt.Printf("%s (%s):\n", pokemon.Name, strings.Join(types, "/"))
+	for _, group := range weaknessGroups {
+		var attackers []string
+		for _, attacking := range pokeapi.TypeNames {
+			if defense[attacking] == group.multiplier {
+				attackers = append(attackers, attacking)
+			}
+		}
+		if len(attackers) > 0 {
+			fmt.Printf("  %s: %s\n", group.label, strings.Join(attackers, ", "))
+		}
+	}
+	return nil
+}
+
+func movesCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	args, options, err := parseOptions(args, "version-group", "method", "lang")
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single pokemon")
+	}
+	method := options["method"]
+	if method != "" && !slices.Contains(pokeapi.LearnMethods, method) {
+		return fmt.Errorf("unknown method %q, expected one of %s", method, strings.Join(pokeapi.LearnMethods, ", "))
+	}
+	pokemon, err := client().CatchPokemon(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "Pokémon", args[0])
+	}
+	versionGroup, ok := options["version-group"]
+	if !ok {
+		versionGroup = pokemon.LatestVersionGroup()
+	}
+	learnset := pokemon.Learnset(versionGroup, method)
+	if len(learnset) == 0 {
+		fmt.Printf("%s learns no moves in %s\n", pokemon.Name, versionGroup)
+		return nil
+	}
+	var names []string
+	for _, entry := range learnset {
+		if !slices.Contains(names, entry.Move) {
+			names = append(names, entry.Move)
+		}
+	}
+	moves, err := client().Moves(ctx, names)
+	if err != nil {
+		return friendlyError(ctx, err, "", "")
+	}
+
+	fmt.Printf("Moves of %s in %s:\n", pokemon.Name, versionGroup)
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "Learned\tMove\tType\tClass\tPower\tAccuracy\tPP\tPriority\tEffect")
+	lang := chosenLanguage(cfg, options)
+	for _, entry := range learnset {
+		move := moves[entry.Move]
+		learned := entry.Method
+		if entry.Method == "level-up" {
+			learned = fmt.Sprintf("level %d", entry.Level)
+		}
+		effect := move.ShortEffect(lang)
+		if effect == "" {
+			effect = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%s\n",
+			learned, entry.Move, move.Type.Name, move.DamageClass.Name,
+			optionalStat(move.Power), optionalStat(move.Accuracy), move.PP, move.Priority, effect)
+	}
+	return w.Flush()
+}
+
+// optionalStat formats a move stat PokeAPI leaves null, such as the power of
+// a status move.
+func optionalStat(value int) string {
+	if value == 0 {
+		return "-"
+	}
+	return strconv.Itoa(value)
+}
+
+// chosenLanguage is the language of localized text, from the --lang option
+// or the language chosen at startup.
+func chosenLanguage(cfg *config.Config, options map[string]string) string {
+	if lang, ok := options["lang"]; ok {
+		return lang
+	}
+	if cfg.Language != "" {
+		return cfg.Language
+	}
+	return pokeapi.DefaultLanguage
+}
+
+func abilityCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	args, options, err := parseOptions(args, "lang")
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single ability")
+	}
+	ability, err := client().Ability(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "ability", args[0])
+	}
+	fmt.Printf("Ability: %s\n", ability.Name)
+	if effect := ability.Effect(chosenLanguage(cfg, options)); effect != "" {
+		fmt.Printf("Effect: %s\n", effect)
+	}
+	fmt.Println("Pokémon:")
+	for _, pokemon := range ability.Pokemon {
+		if pokemon.IsHidden {
+			fmt.Printf("  - %s (hidden)\n", pokemon.Pokemon.Name)
+		} else {
+			fmt.Printf("  - %s\n", pokemon.Pokemon.Name)
+		}
+	}
+	return nil
+}
+
+func itemCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	args, options, err := parseOptions(args, "lang")
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single item")
+	}
+	item, err := client().Item(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "item", args[0])
+	}
+	fmt.Printf("Item: %s\n", item.Name)
+	fmt.Printf("Category: %s\n", item.Category.Name)
+	fmt.Printf("Cost: %d\n", item.Cost)
+	if item.FlingPower > 0 {
+		if item.FlingEffect.Name != "" {
+			fmt.Printf("Fling power: %d (%s)\n", item.FlingPower, item.FlingEffect.Name)
+		} else {
+			fmt.Printf("Fling power: %d\n", item.FlingPower)
+		}
+	}
+	if len(item.Attributes) > 0 {
+		attributes := make([]string, 0, len(item.Attributes))
+		for _, attribute := range item.Attributes {
+			attributes = append(attributes, attribute.Name)
+		}
+		fmt.Printf("Attributes: %s\n", strings.Join(attributes, ", "))
+	}
+	if effect := item.Effect(chosenLanguage(cfg, options)); effect != "" {
+		fmt.Printf("Effect: %s\n", effect)
+	}
+	return nil
+}
+
+func berryCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	args, options, err := parseOptions(args, "lang")
+	if err != nil {
+		return err
+	}
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single berry")
+	}
+	berry, err := client().Berry(ctx, args[0])
+	if err != nil {
+		return friendlyError(ctx, err, "berry", args[0])
+	}
+	// The effect of a berry is described by the item it is held as.
+	item, err := client().Item(ctx, berry.Item.Name)
+	if err != nil {
+		return friendlyError(ctx, err, "item", berry.Item.Name)
+	}
+	fmt.Printf("Berry: %s (%s)\n", berry.Name, berry.Item.Name)
+	fmt.Printf("Firmness: %s\n", berry.Firmness.Name)
+	fmt.Printf("Growth time: %d hours per stage\n", berry.GrowthTime)
+	fmt.Printf("Max harvest: %d\n", berry.MaxHarvest)
+	fmt.Printf("Natural gift: %s, power %d\n", berry.NaturalGiftType.Name, berry.NaturalGiftPower)
+	var flavors []string
+	for _, flavor := range berry.Flavors {
+		if flavor.Potency > 0 {
+			flavors = append(flavors, fmt.Sprintf("%s %d", flavor.Flavor.Name, flavor.Potency))
+		}
+	}
+	if len(flavors) > 0 {
+		fmt.Printf("Flavors: %s\n", strings.Join(flavors, ", "))
+	}
+	if effect := item.Effect(chosenLanguage(cfg, options)); effect != "" {
+		fmt.Printf("Effect: %s\n", effect)
+	}
+	return nil
+}
+
+// takeSwitch removes every "--name" switch from args and reports whether
+// there was one.
+func takeSwitch(args []string, name string) ([]string, bool) {
+	var rest []string
+	found := false
+	for _, arg := range args {
+		if arg == "--"+name {
+			found = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	return rest, found
+}
+
+// parseOptions splits args into positional arguments and the "--name value"
+// or "--name=value" options listed in names.
+func parseOptions(args []string, names ...string) ([]string, map[string]string, error) {
+	var positional []string
+	options := make(map[string]string)
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "" {
+			continue
+		}
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		if !slices.Contains(names, name) {
+			return nil, nil, fmt.Errorf("unknown option --%s", name)
+		}
+		if !hasValue {
+			if i+1 >= len(args) || strings.HasPrefix(args[i+1], "--") {
+				return nil, nil, fmt.Errorf("option --%s needs a value", name)
+			}
+			i++
+			value = args[i]
+		}
+		options[name] = value
+	}
+	return positional, options, nil
+}
+
+func pokedexCommand(ctx context.Context, cfg *config.Config, args []string) error {
 	if len(args) > 0 {
 		return fmt.Errorf("no arguments expected")
 	}
@@ -237,3 +923,257 @@ func pokedexCommand(cfg *config.Config, args []string) error {
 	}
 	return nil
 }
+
+func rateLimitCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("no arguments expected")
+	}
+	limiter := client().RateLimiter()
+	if limiter == nil {
+		fmt.Println("Rate limiting is disabled")
+		return nil
+	}
+	stats := limiter.Stats()
+	fmt.Printf("Rate: %.2f requests/s\n", stats.Rate)
+	fmt.Printf("Burst: %d\n", stats.Burst)
+	fmt.Printf("Available tokens: %.2f\n", stats.Tokens)
+	fmt.Printf("Requests: %d\n", stats.Requests)
+	fmt.Printf("Throttled: %d\n", stats.Throttled)
+	fmt.Printf("Total wait: %s\n", stats.TotalWait.Round(time.Millisecond))
+	return nil
+}
+
+func offlineCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		client().SetOffline(!client().Offline())
+	} else {
+		switch args[0] {
+		case "on":
+			client().SetOffline(true)
+		case "off":
+			client().SetOffline(false)
+		case "ls":
+			if len(args) > 2 {
+				return fmt.Errorf("only one prefix can be listed at a time")
+			}
+			prefix := ""
+			if len(args) == 2 {
+				prefix = args[1]
+			}
+			available := client().Available(prefix)
+			if len(available) == 0 {
+				fmt.Println("No resources available offline")
+				return nil
+			}
+			fmt.Println("Available offline:")
+			for _, key := range available {
+				fmt.Printf("  - %s\n", key)
+			}
+			return nil
+		default:
+			return fmt.Errorf("unknown offline argument %q, expected on, off or ls", args[0])
+		}
+	}
+	if client().Offline() {
+		fmt.Println("Offline mode is on, only cached resources are available")
+	} else {
+		fmt.Println("Offline mode is off")
+	}
+	return nil
+}
+
+func prewarmCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) > 1 {
+		return fmt.Errorf("only the concurrency can be specified")
+	}
+	concurrency := pokeapi.DefaultPrewarmConcurrency
+	if len(args) == 1 {
+		n, err := strconv.Atoi(args[0])
+		if err != nil || n < 1 {
+			return fmt.Errorf("invalid concurrency %q", args[0])
+		}
+		concurrency = n
+	}
+	cfg.Cmd = "prewarm"
+	var stage pokeapi.PrewarmStage
+	result, err := client().Prewarm(ctx, pokeapi.PrewarmOptions{
+		Concurrency: concurrency,
+		Progress: func(p pokeapi.PrewarmProgress) {
+			if stage != "" && stage != p.Stage {
+				fmt.Println()
+			}
+			stage = p.Stage
+			fmt.Printf("\rPrewarming %s: %d/%d", p.Stage, p.Done, p.Total)
+			if p.Failed > 0 {
+				fmt.Printf(" (%d failed)", p.Failed)
+			}
+		},
+	})
+	if stage != "" {
+		fmt.Println()
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Cached %d pages, %d areas and %d pokemon", result.Pages, result.Areas, result.Pokemon)
+	if result.Failed > 0 {
+		fmt.Printf(", %d failed", result.Failed)
+	}
+	fmt.Println()
+	if result.Evicted > 0 {
+		fmt.Printf("Warning: %d entries were evicted to stay within the cache size budget, raise -cache-max-bytes to keep everything\n", result.Evicted)
+	}
+	return nil
+}
+
+func exportCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("expected a single bundle file")
+	}
+	file, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+	n, err := client().Cache().Export(file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(args[0])
+		return err
+	}
+	fmt.Printf("Exported %d entries to %s\n", n, args[0])
+	return nil
+}
+
+func importCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 || len(args) > 2 {
+		return fmt.Errorf("expected a bundle file and an optional merge policy")
+	}
+	policy := cache.MergeNewer
+	if len(args) == 2 {
+		var err error
+		policy, err = cache.ParseMergePolicy(args[1])
+		if err != nil {
+			return err
+		}
+	}
+	file, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	result, err := client().Cache().Import(file, policy)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Imported %d entries from %s, skipped %d\n", result.Imported, args[0], result.Skipped)
+	return nil
+}
+
+func cacheCommand(ctx context.Context, cfg *config.Config, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("expected one of stats, ls, show, purge or ttl")
+	}
+	pokeCache := client().Cache()
+	switch args[0] {
+	case "stats":
+		stats := pokeCache.Stats()
+		fmt.Printf("Entries: %d\n", stats.Entries)
+		if stats.MaxBytes > 0 {
+			fmt.Printf("Size: %d/%d bytes\n", stats.Bytes, stats.MaxBytes)
+		} else {
+			fmt.Printf("Size: %d bytes\n", stats.Bytes)
+		}
+		fmt.Printf("TTL: %s\n", stats.TTL)
+		if stats.HardTTL > stats.TTL {
+			fmt.Printf("Stale until: %s\n", stats.HardTTL)
+		}
+		fmt.Printf("Hits: %d, Misses: %d, Evictions: %d, Expired: %d\n", stats.Hits, stats.Misses, stats.Evictions, stats.Expired)
+		names := make([]string, 0, len(stats.Namespaces))
+		for name := range stats.Namespaces {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			ns := stats.Namespaces[name]
+			fmt.Printf("  - %s: %d entries, %d bytes, %d hits, %d misses, %d evictions\n", name, ns.Entries, ns.Bytes, ns.Hits, ns.Misses, ns.Evictions)
+		}
+	case "ls":
+		if len(args) > 2 {
+			return fmt.Errorf("only one prefix can be listed at a time")
+		}
+		prefix := ""
+		if len(args) == 2 {
+			prefix = args[1]
+		}
+		keys := pokeCache.Keys(prefix)
+		if len(keys) == 0 {
+			fmt.Println("No cached entries")
+			return nil
+		}
+		now := pokeCache.Now()
+		for _, key := range keys {
+			entry, ok := pokeCache.Peek(key)
+			if !ok {
+				continue
+			}
+			state := ""
+			switch {
+			case entry.Stale(now):
+				state = " (stale)"
+			case entry.Expired(now):
+				state = " (expired)"
+			}
+			fmt.Printf("  - %s %d bytes%s\n", key, len(entry.Val), state)
+		}
+	case "show":
+		if len(args) != 2 {
+			return fmt.Errorf("expected a single cache key")
+		}
+		entry, ok := pokeCache.Peek(args[1])
+		if !ok {
+			return fmt.Errorf("%s is not cached", args[1])
+		}
+		fmt.Printf("Key: %s\n", entry.Key)
+		fmt.Printf("Fetched: %s\n", entry.CreatedAt.Format(time.RFC3339))
+		fmt.Printf("Expires: %s\n", entry.ExpiresAt.Format(time.RFC3339))
+		fmt.Printf("Size: %d bytes\n", len(entry.Val))
+		if entry.ETag != "" {
+			fmt.Printf("ETag: %s\n", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			fmt.Printf("Last-Modified: %s\n", entry.LastModified)
+		}
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, entry.Val, "", "  "); err != nil {
+			fmt.Println(string(entry.Val))
+			return nil
+		}
+		fmt.Println(pretty.String())
+	case "purge":
+		if len(args) > 2 {
+			return fmt.Errorf("only one prefix can be purged at a time")
+		}
+		prefix := ""
+		if len(args) == 2 {
+			prefix = args[1]
+		}
+		fmt.Printf("Purged %d entries\n", pokeCache.Purge(prefix))
+	case "ttl":
+		if len(args) > 2 {
+			return fmt.Errorf("expected a single duration")
+		}
+		if len(args) == 2 {
+			ttl, err := time.ParseDuration(args[1])
+			if err != nil || ttl <= 0 {
+				return fmt.Errorf("invalid duration %q", args[1])
+			}
+			pokeCache.SetTTL(ttl)
+		}
+		fmt.Printf("TTL: %s\n", pokeCache.TTL())
+	default:
+		return fmt.Errorf("unknown cache command %q, expected one of stats, ls, show, purge or ttl", args[0])
+	}
+	return nil
+}
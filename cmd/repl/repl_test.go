@@ -1,19 +1,199 @@
 package repl
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"poke-repl/internal/api/pokeapi"
+	"poke-repl/internal/cache"
 	"poke-repl/internal/config"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
 
+var fakeAPI *httptest.Server
+
+const canalaveCityArea = `{"id": 1, "name": "canalave-city-area", "pokemon_encounters": [
+	{"pokemon": {"name": "tentacool"}}, {"pokemon": {"name": "tentacruel"}}, {"pokemon": {"name": "staryu"}},
+	{"pokemon": {"name": "magikarp"}}, {"pokemon": {"name": "gyarados"}}, {"pokemon": {"name": "wingull"}},
+	{"pokemon": {"name": "pelipper"}}, {"pokemon": {"name": "shellos"}}, {"pokemon": {"name": "gastrodon"}},
+	{"pokemon": {"name": "finneon"}}, {"pokemon": {"name": "lumineon"}}
+]}`
+
+// fakeTypeRelations holds the damage relations of the types charizard's
+// weaknesses depend on, every other type deals regular damage.
+var fakeTypeRelations = map[string]string{
+	"fire": `"double_damage_to": [{"name": "grass"}, {"name": "ice"}, {"name": "bug"}, {"name": "steel"}],
+		"half_damage_to": [{"name": "fire"}, {"name": "water"}, {"name": "rock"}, {"name": "dragon"}],
+		"double_damage_from": [{"name": "ground"}, {"name": "rock"}, {"name": "water"}]`,
+	"water":    `"double_damage_to": [{"name": "fire"}, {"name": "ground"}, {"name": "rock"}]`,
+	"grass":    `"half_damage_to": [{"name": "fire"}, {"name": "flying"}]`,
+	"ground":   `"double_damage_to": [{"name": "fire"}], "no_damage_to": [{"name": "flying"}]`,
+	"rock":     `"double_damage_to": [{"name": "fire"}, {"name": "flying"}]`,
+	"electric": `"double_damage_to": [{"name": "water"}, {"name": "flying"}]`,
+	"fighting": `"half_damage_to": [{"name": "flying"}]`,
+}
+
+var fakeMoves = map[string]string{
+	"thunderbolt": `{"name": "thunderbolt", "power": 90, "accuracy": 100, "pp": 15, "priority": 0, "effect_chance": 10,
+		"type": {"name": "electric"}, "damage_class": {"name": "special"},
+		"effect_entries": [{"short_effect": "Has a $effect_chance% chance to paralyze the target.", "language": {"name": "en"}}]}`,
+	"thunder-shock": `{"name": "thunder-shock", "power": 40, "accuracy": 100, "pp": 30, "priority": 0, "effect_chance": 10,
+		"type": {"name": "electric"}, "damage_class": {"name": "special"},
+		"effect_entries": [
+			{"short_effect": "Has a $effect_chance% chance to paralyze the target.", "language": {"name": "en"}},
+			{"short_effect": "A $effect_chance% de chances de paralyser la cible.", "language": {"name": "fr"}}
+		]}`,
+	"thunder-wave": `{"name": "thunder-wave", "power": null, "accuracy": 90, "pp": 20, "priority": 0,
+		"type": {"name": "electric"}, "damage_class": {"name": "status"},
+		"effect_entries": [{"short_effect": "Paralyzes the target.", "language": {"name": "en"}}]}`,
+}
+
+func fakePokeAPI() *httptest.Server {
+	mux := http.NewServeMux()
+	var server *httptest.Server
+	mux.HandleFunc("/location-area", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("offset") == "20" {
+			fmt.Fprintf(w, `{"count": 1, "next": null, "previous": "%[1]s/location-area?offset=0&limit=20", "results": []}`, server.URL)
+			return
+		}
+		fmt.Fprintf(w, `{"count": 1, "next": "%[1]s/location-area?offset=20&limit=20", "previous": null, "results": [{"name": "canalave-city-area", "url": "%[1]s/location-area/canalave-city-area/"}]}`, server.URL)
+	})
+	mux.HandleFunc("/location-area/canalave-city-area", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(canalaveCityArea))
+	})
+	mux.HandleFunc("/pokemon", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count": 4, "next": null, "previous": null, "results": [{"name": "caterpie"}, {"name": "mew"}, {"name": "pichu"}, {"name": "pikachu"}]}`))
+	})
+	mux.HandleFunc("/pokemon-species/pikachu", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": 25, "name": "pikachu", "evolution_chain": {"url": "%s/evolution-chain/10/"},
+			"genera": [{"genus": "Mouse Pokémon", "language": {"name": "en"}}, {"genus": "Pokémon Souris", "language": {"name": "fr"}}],
+			"flavor_text_entries": [
+				{"flavor_text": "When several of\nthese POKéMON\fgather, their", "language": {"name": "en"}, "version": {"name": "red"}},
+				{"flavor_text": "It keeps its tail\nraised to monitor\fits surroundings.", "language": {"name": "en"}, "version": {"name": "yellow"}},
+				{"flavor_text": "Il lui arrive de remettre en marche", "language": {"name": "fr"}, "version": {"name": "x"}}
+			]}`, server.URL)
+	})
+	mux.HandleFunc("/pokemon/pikachu", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"id": 25, "name": "pikachu", "base_experience": 112, "species": {"name": "pikachu", "url": "%s/pokemon-species/25/"},
+			"moves": [
+				{"move": {"name": "thunderbolt"}, "version_group_details": [
+					{"level_learned_at": 0, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}, "move_learn_method": {"name": "machine"}},
+					{"level_learned_at": 0, "version_group": {"name": "yellow", "url": "https://pokeapi.co/api/v2/version-group/2/"}, "move_learn_method": {"name": "machine"}}
+				]},
+				{"move": {"name": "thunder-shock"}, "version_group_details": [
+					{"level_learned_at": 1, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}, "move_learn_method": {"name": "level-up"}},
+					{"level_learned_at": 1, "version_group": {"name": "yellow", "url": "https://pokeapi.co/api/v2/version-group/2/"}, "move_learn_method": {"name": "level-up"}}
+				]},
+				{"move": {"name": "thunder-wave"}, "version_group_details": [
+					{"level_learned_at": 9, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}, "move_learn_method": {"name": "level-up"}}
+				]}
+			]}`, server.URL)
+	})
+	mux.HandleFunc("/move/{name}", func(w http.ResponseWriter, r *http.Request) {
+		move, ok := fakeMoves[r.PathValue("name")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(move))
+	})
+	mux.HandleFunc("/evolution-chain/10", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 10, "chain": {"species": {"name": "pichu"}, "is_baby": true, "evolves_to": [
+			{"species": {"name": "pikachu"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_happiness": 220}], "evolves_to": [
+				{"species": {"name": "raichu"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "thunder-stone"}}], "evolves_to": []}
+			]}
+		]}}`))
+	})
+	mux.HandleFunc("/pokemon/charizard", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 6, "name": "charizard", "base_experience": 267, "types": [{"slot": 1, "type": {"name": "fire"}}, {"slot": 2, "type": {"name": "flying"}}]}`))
+	})
+	mux.HandleFunc("/type/{name}", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name": %q, "damage_relations": {%s}}`, r.PathValue("name"), fakeTypeRelations[r.PathValue("name")])
+	})
+	mux.HandleFunc("/ability/static", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 9, "name": "static",
+			"effect_entries": [{"short_effect": "Has a 30% chance of paralyzing attacking Pokémon on contact.", "language": {"name": "en"}}],
+			"flavor_text_entries": [{"flavor_text": "Peut paralyser\nau contact.", "language": {"name": "fr"}, "version_group": {"name": "x-y"}}],
+			"pokemon": [{"is_hidden": false, "slot": 1, "pokemon": {"name": "pikachu"}}, {"is_hidden": true, "slot": 3, "pokemon": {"name": "electrike"}}]}`))
+	})
+	mux.HandleFunc("/item/light-ball", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 213, "name": "light-ball", "cost": 1000, "fling_power": 30,
+			"fling_effect": {"name": "paralyze"}, "category": {"name": "species-specific"},
+			"attributes": [{"name": "holdable"}, {"name": "holdable-active"}],
+			"effect_entries": [{"short_effect": "Doubles Pikachu's Attack and Special Attack.", "language": {"name": "en"}}]}`))
+	})
+	mux.HandleFunc("/item/cheri-berry", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 126, "name": "cheri-berry", "cost": 20, "category": {"name": "medicine"},
+			"effect_entries": [{"short_effect": "Consumed when paralyzed to cure paralysis.", "language": {"name": "en"}}]}`))
+	})
+	mux.HandleFunc("/berry/cheri", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "cheri", "growth_time": 3, "max_harvest": 5, "natural_gift_power": 60,
+			"firmness": {"name": "soft"}, "natural_gift_type": {"name": "fire"}, "item": {"name": "cheri-berry"},
+			"flavors": [{"potency": 10, "flavor": {"name": "spicy"}}, {"potency": 0, "flavor": {"name": "dry"}}]}`))
+	})
+	mux.HandleFunc("/region", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"count": 2, "next": null, "previous": null, "results": [{"name": "sinnoh"}, {"name": "kanto"}]}`))
+	})
+	mux.HandleFunc("/region/sinnoh", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 4, "name": "sinnoh", "main_generation": {"name": "generation-iv"},
+			"locations": [{"name": "canalave-city"}, {"name": "sinnoh-route-201"}]}`))
+	})
+	mux.HandleFunc("/location/canalave-city", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "canalave-city", "region": {"name": "sinnoh"}, "areas": [{"name": "canalave-city-area"}]}`))
+	})
+	mux.HandleFunc("/location/sinnoh-route-201", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 2, "name": "sinnoh-route-201", "region": {"name": "sinnoh"}, "areas": [{"name": "sinnoh-route-201-area"}]}`))
+	})
+	mux.HandleFunc("/location-area/viridian-forest-area", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 321, "name": "viridian-forest-area",
+			"encounter_method_rates": [{"encounter_method": {"name": "walk"}, "version_details": [{"rate": 8, "version": {"name": "red"}}, {"rate": 4, "version": {"name": "yellow"}}]}],
+			"pokemon_encounters": [
+				{"pokemon": {"name": "pikachu"}, "version_details": [
+					{"version": {"name": "red"}, "encounter_details": [{"min_level": 3, "max_level": 3, "chance": 5, "method": {"name": "walk"}}]},
+					{"version": {"name": "yellow"}, "encounter_details": [
+						{"min_level": 3, "max_level": 3, "chance": 5, "method": {"name": "walk"}},
+						{"min_level": 5, "max_level": 5, "chance": 5, "method": {"name": "walk"}}
+					]}
+				]},
+				{"pokemon": {"name": "caterpie"}, "version_details": [
+					{"version": {"name": "red"}, "encounter_details": [
+						{"min_level": 3, "max_level": 3, "chance": 20, "method": {"name": "walk"}},
+						{"min_level": 5, "max_level": 5, "chance": 30, "method": {"name": "walk"}}
+					]}
+				]}
+			]}`))
+	})
+	mux.HandleFunc("/pokemon/caterpie", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 10, "name": "caterpie", "base_experience": 39}`))
+	})
+	mux.HandleFunc("/pokemon/mew", func(w http.ResponseWriter, r *http.Request) {
+		// A very high base experience keeps the escape deterministic enough for the test.
+		w.Write([]byte(`{"id": 151, "name": "mew", "base_experience": 1000000}`))
+	})
+	server = httptest.NewServer(mux)
+	return server
+}
+
+func TestMain(m *testing.M) {
+	fakeAPI = fakePokeAPI()
+	UseClient(pokeapi.NewClient(pokeapi.WithBaseURL(fakeAPI.URL), pokeapi.WithRateLimiter(pokeapi.NewRateLimiter(1000, 100))))
+	code := m.Run()
+	fakeAPI.Close()
+	os.Exit(code)
+}
+
 func TestCommandsMap(t *testing.T) {
 	commands := CommandsMap()
-	if len(commands) != 10 {
-		t.Errorf("Expected 10 commands, got %d", len(commands))
+	if len(commands) != 26 {
+		t.Errorf("Expected 26 commands, got %d", len(commands))
 	}
 }
 
@@ -33,10 +213,18 @@ func TestLookupCommandNotFound(t *testing.T) {
 		t.Errorf("Expected error, got nil")
 	}
 }
+
+func TestLookupCommandSuggestions(t *testing.T) {
+	_, err := LookupCommand("hepl")
+	assert.EqualError(t, err, "command not found, did you mean help?")
+
+	_, err = LookupCommand("exprt")
+	assert.EqualError(t, err, "command not found, did you mean export?")
+}
 func TestCommandHelp(t *testing.T) {
 	cfg := &config.Config{}
 	args := []string{}
-	err := commandHelp(cfg, args)
+	err := commandHelp(context.Background(), cfg, args)
 	if err != nil {
 		t.Errorf("Expected no error, got %s", err)
 	}
@@ -45,20 +233,21 @@ func TestClearScreen(t *testing.T) {
 	cfg := &config.Config{}
 	args := []string{}
 
-	err := clearScreen(cfg, args)
+	err := clearScreen(context.Background(), cfg, args)
 	if err != nil {
 		t.Errorf("Expected no error, got %s", err)
 	}
 }
 
-// Exit command is not tested because it exits the program,
-// we could test it by mocking the os.Exit function
-// but it's not worth it for this application.
+func TestExitCommand(t *testing.T) {
+	err := commandExit(context.Background(), &config.Config{}, []string{})
+	assert.ErrorIs(t, err, ErrExit)
+}
 
 func TestMapCommand(t *testing.T) {
 	cfg := &config.Config{}
 	args := []string{}
-	err := mapCommand(cfg, args)
+	err := mapCommand(context.Background(), cfg, args)
 	if err != nil {
 		t.Errorf("Expected no error, got %s", err)
 	}
@@ -68,14 +257,14 @@ func TestPageNavigation(t *testing.T) {
 	tests := []struct {
 		name      string
 		setupCfg  func() *config.Config
-		testFunc  func(cfg *config.Config, args []string) error
+		testFunc  func(ctx context.Context, cfg *config.Config, args []string) error
 		expectErr bool
 	}{
 		{
 			name: "PreviousPage with valid URL",
 			setupCfg: func() *config.Config {
 				return &config.Config{
-					PreviousUrl: "https://pokeapi.co/api/v2/location/?offset=40&limit=20",
+					PreviousUrl: fakeAPI.URL + "/location-area?offset=0&limit=20",
 					Referrer:    "previous",
 					Cmd:         "map",
 				}
@@ -99,7 +288,7 @@ func TestPageNavigation(t *testing.T) {
 			name: "NextPage with valid URL",
 			setupCfg: func() *config.Config {
 				return &config.Config{
-					NextUrl:  "https://pokeapi.co/api/v2/location/?offset=20&limit=20",
+					NextUrl:  fakeAPI.URL + "/location-area?offset=20&limit=20",
 					Referrer: "next",
 					Cmd:      "map",
 				}
@@ -124,7 +313,7 @@ func TestPageNavigation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			cfg := tt.setupCfg()
-			err := tt.testFunc(cfg, args)
+			err := tt.testFunc(context.Background(), cfg, args)
 			if tt.expectErr && err == nil {
 				t.Errorf("Expected an error, got nil")
 			} else if !tt.expectErr && err != nil {
@@ -140,17 +329,89 @@ func TestInspectCommand(t *testing.T) {
 		Name:           "Pikachu",
 		BaseExperience: 50,
 	})
-	err := inspectCommand(cfg, args)
+	err := inspectCommand(context.Background(), cfg, args)
 	if err != nil {
 		t.Errorf("Expected no error, got %s", err)
 	}
 }
 
+func TestInspectCommandSpecies(t *testing.T) {
+	var pikachu pokeapi.PokemonResult
+	err := json.Unmarshal([]byte(`{"name": "pikachu", "height": 4, "weight": 60, "abilities": [
+		{"is_hidden": true, "slot": 3, "ability": {"name": "lightning-rod"}},
+		{"is_hidden": false, "slot": 1, "ability": {"name": "static"}}
+	]}`), &pikachu)
+	assert.NoError(t, err)
+	pokeDex.AddPokemon(pikachu)
+	base := "Name: pikachu\n%sHeight: 4\nWeight: 60\nStats:\nTypes:\nAbilities:\n  - static\n  - lightning-rod (hidden)\n%s"
+
+	tests := []struct {
+		name           string
+		cfg            config.Config
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:           "latest english entry by default",
+			args:           []string{"pikachu"},
+			expectedOutput: fmt.Sprintf(base, "Genus: Mouse Pokémon\n", "Pokédex entry (yellow): It keeps its tail raised to monitor its surroundings.\n"),
+		},
+		{
+			name:           "version chosen at startup",
+			cfg:            config.Config{Language: "en", Version: "red"},
+			args:           []string{"pikachu"},
+			expectedOutput: fmt.Sprintf(base, "Genus: Mouse Pokémon\n", "Pokédex entry (red): When several of these POKéMON gather, their\n"),
+		},
+		{
+			name:           "language and version options",
+			args:           []string{"pikachu", "--lang", "fr", "--version=x"},
+			expectedOutput: fmt.Sprintf(base, "Genus: Pokémon Souris\n", "Pokédex entry (x): Il lui arrive de remettre en marche\n"),
+		},
+		{
+			name:           "no entry for the version",
+			args:           []string{"pikachu", "--version", "gold"},
+			expectedOutput: fmt.Sprintf(base, "Genus: Mouse Pokémon\n", ""),
+		},
+		{
+			name:          "unknown option",
+			args:          []string{"pikachu", "--shiny"},
+			expectedError: "unknown option --shiny",
+		},
+		{
+			name:          "option without a value",
+			args:          []string{"pikachu", "--lang"},
+			expectedError: "option --lang needs a value",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := inspectCommand(context.Background(), &tt.cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+}
+
 func TestInspectCommandNoPokemon(t *testing.T) {
 	cfg := &config.Config{}
 	args := []string{"Charmander"}
 
-	err := inspectCommand(cfg, args)
+	err := inspectCommand(context.Background(), cfg, args)
 	if err == nil {
 		t.Errorf("Expected error, got nil")
 	}
@@ -160,7 +421,7 @@ func TestInspectCommandNoArgs(t *testing.T) {
 	cfg := &config.Config{}
 	args := []string{}
 
-	err := inspectCommand(cfg, args)
+	err := inspectCommand(context.Background(), cfg, args)
 	if err == nil {
 		t.Errorf("Expected no error, got %s", err)
 	}
@@ -169,7 +430,7 @@ func TestPokedexCommand(t *testing.T) {
 	cfg := &config.Config{}
 	args := []string{}
 
-	err := pokedexCommand(cfg, args)
+	err := pokedexCommand(context.Background(), cfg, args)
 	if err != nil {
 		t.Errorf("Expected no error, got %s", err)
 	}
@@ -179,7 +440,7 @@ func TestPokedexCommandWithArgs(t *testing.T) {
 	cfg := &config.Config{}
 	args := []string{"Pikachu"}
 
-	err := pokedexCommand(cfg, args)
+	err := pokedexCommand(context.Background(), cfg, args)
 	if err == nil {
 		t.Errorf("Expected error, got nil")
 	}
@@ -203,6 +464,38 @@ func TestExploreCommand(t *testing.T) {
 			args:          []string{"area1", "area2"},
 			expectedError: "only one area can be explored at a time",
 		},
+		{
+			name:          "area does not exist",
+			args:          []string{"canalave-city-aera"},
+			expectedError: "no area named canalave-city-aera, did you mean canalave-city-area?",
+		},
+		{
+			name:          "unknown option",
+			args:          []string{"viridian-forest-area", "--verbose"},
+			expectedError: "unknown option --verbose",
+		},
+		{
+			name: "details of the first version",
+			args: []string{"viridian-forest-area", "--details"},
+			expectedOutput: "Encounters in viridian-forest-area (red):\n" +
+				"Pokemon   Method  Levels  Chance\n" +
+				"caterpie  walk    3-5     50%\n" +
+				"pikachu   walk    3       5%\n" +
+				"Encounter rates:\n  - walk: 8\n",
+		},
+		{
+			name: "details of a chosen version",
+			args: []string{"viridian-forest-area", "--details", "--version", "yellow"},
+			expectedOutput: "Encounters in viridian-forest-area (yellow):\n" +
+				"Pokemon  Method  Levels  Chance\n" +
+				"pikachu  walk    3-5     10%\n" +
+				"Encounter rates:\n  - walk: 4\n",
+		},
+		{
+			name:           "details of a version without encounters",
+			args:           []string{"viridian-forest-area", "--details", "--version=gold"},
+			expectedOutput: "No encounters in viridian-forest-area for gold, try one of red, yellow\n",
+		},
 		{
 			name:           "successful exploration",
 			args:           []string{"canalave-city-area"},
@@ -216,7 +509,7 @@ func TestExploreCommand(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			err := exploreCommand(cfg, tt.args)
+			err := exploreCommand(context.Background(), cfg, tt.args)
 
 			w.Close()
 			out, _ := io.ReadAll(r)
@@ -241,11 +534,26 @@ func TestCatchCommand(t *testing.T) {
 		expectedError  string
 		expectedOutput string
 	}{
+		{
+			name:          "no pokemon specified",
+			args:          []string{},
+			expectedError: "no pokemon specified",
+		},
 		{
 			name:          "more than one pokemon specified",
 			args:          []string{"pikachu", "bulbasaur"},
 			expectedError: "only one pokemon can be caught at a time",
 		},
+		{
+			name:          "pokemon does not exist",
+			args:          []string{"pikachuu"},
+			expectedError: "no Pokémon named pikachuu, did you mean pikachu?",
+		},
+		{
+			name:          "pokemon does not exist and nothing is close",
+			args:          []string{"bulbasaur"},
+			expectedError: "no Pokémon named bulbasaur",
+		},
 		{
 			name:           "pokemon caught successfully",
 			args:           []string{"caterpie"},
@@ -265,7 +573,120 @@ func TestCatchCommand(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			err := catchCommand(cfg, tt.args)
+			err := catchCommand(context.Background(), cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+}
+
+func TestEvolutionsCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "no pokemon specified",
+			args:          []string{},
+			expectedError: "expected a single pokemon",
+		},
+		{
+			name:          "pokemon does not exist",
+			args:          []string{"pikachuu"},
+			expectedError: "no Pokémon named pikachuu, did you mean pikachu?",
+		},
+		{
+			name:           "evolution chain",
+			args:           []string{"pikachu"},
+			expectedOutput: "pichu\n`-- pikachu (level up, happiness 220)\n    `-- raichu (use thunder-stone)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := evolutionsCommand(context.Background(), cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+}
+
+func TestPrintEvolutions(t *testing.T) {
+	var chain pokeapi.EvolutionChainResult
+	err := json.Unmarshal([]byte(`{"chain": {"species": {"name": "oddish"}, "evolves_to": [
+		{"species": {"name": "gloom"}, "evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 21}], "evolves_to": [
+			{"species": {"name": "vileplume"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "leaf-stone"}}]},
+			{"species": {"name": "bellossom"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "sun-stone"}}]}
+		]},
+		{"species": {"name": "shiinotic"}}
+	]}}`), &chain)
+	assert.NoError(t, err)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	printEvolutions(chain.Chain.EvolvesTo, "")
+	w.Close()
+	out, _ := io.ReadAll(r)
+	os.Stdout = oldStdout
+
+	assert.Equal(t, "|-- gloom (level 21)\n|   |-- vileplume (use leaf-stone)\n|   `-- bellossom (use sun-stone)\n`-- shiinotic\n", string(out))
+}
+
+func TestTypeCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "no type specified",
+			args:          []string{},
+			expectedError: "expected a single type",
+		},
+		{
+			name:           "damage relations",
+			args:           []string{"fire"},
+			expectedOutput: "Type: fire\nAttacking:\n  2x: grass, ice, bug, steel\n  ½x: fire, water, rock, dragon\nDefending:\n  2x: ground, rock, water\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := typeCommand(context.Background(), cfg, tt.args)
 
 			w.Close()
 			out, _ := io.ReadAll(r)
@@ -280,3 +701,548 @@ func TestCatchCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestWeaknessCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "no pokemon specified",
+			args:          []string{},
+			expectedError: "expected a single pokemon",
+		},
+		{
+			name:           "dual type pokemon",
+			args:           []string{"charizard"},
+			expectedOutput: "charizard (fire/flying):\n  4x: rock\n  2x: water, electric\n  ½x: fighting, fire\n  ¼x: grass\n  immune: ground\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := weaknessCommand(context.Background(), cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+}
+
+func TestMovesCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "no pokemon specified",
+			args:          []string{},
+			expectedError: "expected a single pokemon",
+		},
+		{
+			name:          "unknown method",
+			args:          []string{"pikachu", "--method", "trade"},
+			expectedError: `unknown method "trade", expected one of level-up, machine, egg, tutor`,
+		},
+		{
+			name: "latest version group by default",
+			args: []string{"pikachu"},
+			expectedOutput: "Moves of pikachu in yellow:\n" +
+				"Learned  Move           Type      Class    Power  Accuracy  PP  Priority  Effect\n" +
+				"level 1  thunder-shock  electric  special  40     100       30  0         Has a 10% chance to paralyze the target.\n" +
+				"machine  thunderbolt    electric  special  90     100       15  0         Has a 10% chance to paralyze the target.\n",
+		},
+		{
+			name: "version group and method",
+			args: []string{"pikachu", "--version-group", "red-blue", "--method", "level-up"},
+			expectedOutput: "Moves of pikachu in red-blue:\n" +
+				"Learned  Move           Type      Class    Power  Accuracy  PP  Priority  Effect\n" +
+				"level 1  thunder-shock  electric  special  40     100       30  0         Has a 10% chance to paralyze the target.\n" +
+				"level 9  thunder-wave   electric  status   -      90        20  0         Paralyzes the target.\n",
+		},
+		{
+			name: "effects in another language",
+			args: []string{"pikachu", "--lang", "fr"},
+			expectedOutput: "Moves of pikachu in yellow:\n" +
+				"Learned  Move           Type      Class    Power  Accuracy  PP  Priority  Effect\n" +
+				"level 1  thunder-shock  electric  special  40     100       30  0         A 10% de chances de paralyser la cible.\n" +
+				"machine  thunderbolt    electric  special  90     100       15  0         -\n",
+		},
+		{
+			name:           "no moves in version group",
+			args:           []string{"pikachu", "--version-group", "gold-silver"},
+			expectedOutput: "pikachu learns no moves in gold-silver\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := movesCommand(context.Background(), cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+
+	command, err := LookupCommand("moves")
+	assert.NoError(t, err)
+	assert.True(t, command.LongRunning())
+}
+
+func TestAbilityCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "no ability specified",
+			args:          []string{},
+			expectedError: "expected a single ability",
+		},
+		{
+			name:          "ability does not exist",
+			args:          []string{"statik"},
+			expectedError: "no ability named statik",
+		},
+		{
+			name:           "ability with hidden holders",
+			args:           []string{"static"},
+			expectedOutput: "Ability: static\nEffect: Has a 30% chance of paralyzing attacking Pokémon on contact.\nPokémon:\n  - pikachu\n  - electrike (hidden)\n",
+		},
+		{
+			name:           "localized effect",
+			args:           []string{"static", "--lang", "fr"},
+			expectedOutput: "Ability: static\nEffect: Peut paralyser au contact.\nPokémon:\n  - pikachu\n  - electrike (hidden)\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := abilityCommand(context.Background(), cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+}
+
+func TestItemCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "no item specified",
+			args:          []string{},
+			expectedError: "expected a single item",
+		},
+		{
+			name:          "item does not exist",
+			args:          []string{"dark-ball"},
+			expectedError: "no item named dark-ball",
+		},
+		{
+			name: "item",
+			args: []string{"light-ball"},
+			expectedOutput: "Item: light-ball\nCategory: species-specific\nCost: 1000\nFling power: 30 (paralyze)\n" +
+				"Attributes: holdable, holdable-active\nEffect: Doubles Pikachu's Attack and Special Attack.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := itemCommand(context.Background(), cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+}
+
+func TestBerryCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "no berry specified",
+			args:          []string{},
+			expectedError: "expected a single berry",
+		},
+		{
+			name: "berry with its item effect",
+			args: []string{"cheri"},
+			expectedOutput: "Berry: cheri (cheri-berry)\nFirmness: soft\nGrowth time: 3 hours per stage\nMax harvest: 5\n" +
+				"Natural gift: fire, power 60\nFlavors: spicy 10\nEffect: Consumed when paralyzed to cure paralysis.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := berryCommand(context.Background(), cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+}
+
+func TestRegionCommands(t *testing.T) {
+	tests := []struct {
+		name           string
+		command        func(ctx context.Context, cfg *config.Config, args []string) error
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:           "regions",
+			command:        regionsCommand,
+			expectedOutput: "- kanto\n- sinnoh\n",
+		},
+		{
+			name:           "region",
+			command:        regionCommand,
+			args:           []string{"sinnoh"},
+			expectedOutput: "Region: sinnoh\nGeneration: generation-iv\nLocations:\n  - canalave-city\n  - sinnoh-route-201\n",
+		},
+		{
+			name:          "region does not exist",
+			command:       regionCommand,
+			args:          []string{"sinoh"},
+			expectedError: "no region named sinoh, did you mean sinnoh?",
+		},
+		{
+			name:           "location",
+			command:        locationCommand,
+			args:           []string{"canalave-city"},
+			expectedOutput: "Location: canalave-city\nRegion: sinnoh\nAreas:\n  - canalave-city-area\n",
+		},
+		{
+			name:           "map of a region",
+			command:        mapCommand,
+			args:           []string{"sinnoh"},
+			expectedOutput: "- canalave-city-area\n- sinnoh-route-201-area\n",
+		},
+		{
+			name:          "map of an unknown region",
+			command:       mapCommand,
+			args:          []string{"kantoo"},
+			expectedError: "no region named kantoo, did you mean kanto?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := tt.command(context.Background(), &config.Config{}, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+}
+
+func TestMapRegionStopsPaging(t *testing.T) {
+	cfg := &config.Config{}
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	assert.NoError(t, mapCommand(context.Background(), cfg, nil))
+	assert.NotEmpty(t, cfg.NextUrl)
+	assert.NoError(t, mapCommand(context.Background(), cfg, []string{"sinnoh"}))
+
+	w.Close()
+	io.ReadAll(r)
+	os.Stdout = oldStdout
+	assert.EqualError(t, nextPage(context.Background(), cfg, nil), "no next page available")
+
+	command, err := LookupCommand("map")
+	assert.NoError(t, err)
+	assert.True(t, command.LongRunning())
+}
+
+func TestRateLimitCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	err := rateLimitCommand(context.Background(), cfg, []string{})
+	if err != nil {
+		t.Errorf("Expected no error, got %s", err)
+	}
+
+	err = rateLimitCommand(context.Background(), cfg, []string{"extra"})
+	if err == nil {
+		t.Errorf("Expected error, got nil")
+	}
+}
+
+func TestOfflineCommand(t *testing.T) {
+	cfg := &config.Config{}
+	defer client().SetOffline(false)
+
+	err := offlineCommand(context.Background(), cfg, []string{"on"})
+	assert.NoError(t, err)
+	assert.True(t, client().Offline())
+
+	err = exploreCommand(context.Background(), cfg, []string{"never-fetched-area"})
+	assert.ErrorIs(t, err, pokeapi.ErrOffline)
+
+	err = offlineCommand(context.Background(), cfg, []string{})
+	assert.NoError(t, err)
+	assert.False(t, client().Offline())
+
+	err = offlineCommand(context.Background(), cfg, []string{"sideways"})
+	assert.Error(t, err)
+}
+
+func TestOfflineCommandLs(t *testing.T) {
+	cfg := &config.Config{}
+	err := exploreCommand(context.Background(), cfg, []string{"canalave-city-area"})
+	assert.NoError(t, err)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err = offlineCommand(context.Background(), cfg, []string{"ls", "location-area/canalave"})
+
+	w.Close()
+	out, _ := io.ReadAll(r)
+	os.Stdout = oldStdout
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Available offline:\n  - location-area/canalave-city-area\n", string(out))
+}
+
+func TestPrewarmCommand(t *testing.T) {
+	cfg := &config.Config{}
+
+	err := prewarmCommand(context.Background(), cfg, []string{"zero"})
+	assert.Error(t, err)
+
+	err = prewarmCommand(context.Background(), cfg, []string{"2"})
+	assert.NoError(t, err)
+	assert.Equal(t, "prewarm", cfg.Cmd)
+
+	command, err := LookupCommand("prewarm")
+	assert.NoError(t, err)
+	assert.True(t, command.LongRunning())
+}
+
+func TestExportImportCommands(t *testing.T) {
+	cfg := &config.Config{}
+	bundle := filepath.Join(t.TempDir(), "pokecache.tar.gz")
+
+	err := exploreCommand(context.Background(), cfg, []string{"canalave-city-area"})
+	assert.NoError(t, err)
+
+	err = exportCommand(context.Background(), cfg, []string{bundle})
+	assert.NoError(t, err)
+
+	err = importCommand(context.Background(), cfg, []string{bundle, "overwrite"})
+	assert.NoError(t, err)
+
+	err = importCommand(context.Background(), cfg, []string{bundle, "sometimes"})
+	assert.Error(t, err)
+
+	err = importCommand(context.Background(), cfg, []string{filepath.Join(t.TempDir(), "missing.tar.gz")})
+	assert.Error(t, err)
+
+	err = exportCommand(context.Background(), cfg, []string{})
+	assert.Error(t, err)
+}
+
+func TestCacheCommand(t *testing.T) {
+	cfg := &config.Config{}
+	err := exploreCommand(context.Background(), cfg, []string{"canalave-city-area"})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		args           []string
+		expectedError  string
+		expectedOutput string
+	}{
+		{
+			name:          "no subcommand",
+			args:          []string{},
+			expectedError: "expected one of stats, ls, show, purge or ttl",
+		},
+		{
+			name:           "ls with prefix",
+			args:           []string{"ls", "location-area/canalave"},
+			expectedOutput: fmt.Sprintf("  - location-area/canalave-city-area %d bytes\n", len(canalaveCityArea)),
+		},
+		{
+			name:          "show missing key",
+			args:          []string{"show", "pokemon/missingno"},
+			expectedError: "pokemon/missingno is not cached",
+		},
+		{
+			name:          "invalid ttl",
+			args:          []string{"ttl", "forever"},
+			expectedError: "invalid duration \"forever\"",
+		},
+		{
+			name:           "ttl",
+			args:           []string{"ttl", "1h"},
+			expectedOutput: "TTL: 1h0m0s\n",
+		},
+		{
+			name:           "purge with prefix",
+			args:           []string{"purge", "location-area/canalave"},
+			expectedOutput: "Purged 1 entries\n",
+		},
+		{
+			name:          "unknown subcommand",
+			args:          []string{"defrag"},
+			expectedError: "unknown cache command \"defrag\", expected one of stats, ls, show, purge or ttl",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := cacheCommand(context.Background(), cfg, tt.args)
+
+			w.Close()
+			out, _ := io.ReadAll(r)
+			os.Stdout = oldStdout
+
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedOutput, string(out))
+			}
+		})
+	}
+
+	assert.NoError(t, cacheCommand(context.Background(), cfg, []string{"stats"}))
+	assert.NoError(t, exploreCommand(context.Background(), cfg, []string{"canalave-city-area"}))
+	assert.NoError(t, cacheCommand(context.Background(), cfg, []string{"show", "location-area/canalave-city-area"}))
+}
+
+func TestCacheLsStates(t *testing.T) {
+	now := time.Now()
+	storage := cache.NewMemoryStorage()
+	storage.Set(cache.Entry{Key: "pokemon/fresh", Val: []byte("{}"), ExpiresAt: now.Add(time.Hour)})
+	storage.Set(cache.Entry{Key: "pokemon/stale", Val: []byte("{}"), ExpiresAt: now.Add(-time.Hour), HardExpiresAt: now.Add(time.Hour)})
+	storage.Set(cache.Entry{Key: "pokemon/expired", Val: []byte("{}"), ExpiresAt: now.Add(-2 * time.Hour), HardExpiresAt: now.Add(-time.Hour)})
+	pokeCache := cache.NewCache(time.Hour, cache.WithStorage(storage))
+	defer pokeCache.Close()
+
+	previous := client()
+	UseClient(pokeapi.NewClient(pokeapi.WithCache(pokeCache)))
+	defer UseClient(previous)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	err := cacheCommand(context.Background(), &config.Config{}, []string{"ls", "pokemon/"})
+
+	w.Close()
+	out, _ := io.ReadAll(r)
+	os.Stdout = oldStdout
+
+	assert.NoError(t, err)
+	assert.Equal(t, "  - pokemon/expired 2 bytes (expired)\n"+
+		"  - pokemon/fresh 2 bytes\n"+
+		"  - pokemon/stale 2 bytes (stale)\n", string(out))
+}
@@ -0,0 +1,60 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+type AbilityResult struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	IsMainSeries  bool          `json:"is_main_series"`
+	Generation    NamedResource `json:"generation"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		FlavorText   string        `json:"flavor_text"`
+		Language     NamedResource `json:"language"`
+		VersionGroup NamedResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	Pokemon []struct {
+		IsHidden bool          `json:"is_hidden"`
+		Slot     int           `json:"slot"`
+		Pokemon  NamedResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+// Effect returns the short effect text of the ability in language. PokeAPI
+// only writes effects in a few languages, so it falls back to the flavor
+// text of the most recent game, and to an empty string.
+func (a *AbilityResult) Effect(language string) string {
+	for _, entry := range a.EffectEntries {
+		if entry.Language.Name == language {
+			return entry.ShortEffect
+		}
+	}
+	for i := len(a.FlavorTextEntries) - 1; i >= 0; i-- {
+		if entry := a.FlavorTextEntries[i]; entry.Language.Name == language {
+			return strings.Join(strings.Fields(entry.FlavorText), " ")
+		}
+	}
+	return ""
+}
+
+func (c *Client) Ability(ctx context.Context, name string) (*AbilityResult, error) {
+	key, url := c.resourceURL("ability", name)
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result AbilityResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "ability", Err: err}
+	}
+	return &result, nil
+}
@@ -0,0 +1,47 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAbility(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ability/static" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id": 9, "name": "static", "is_main_series": true,
+			"effect_entries": [{"short_effect": "Has a 30% chance of paralyzing attacking Pokémon on contact.", "language": {"name": "en"}}],
+			"flavor_text_entries": [
+				{"flavor_text": "Contact with the\nPokémon may cause\nparalysis.", "language": {"name": "en"}, "version_group": {"name": "x-y"}},
+				{"flavor_text": "Peut paralyser\nau contact.", "language": {"name": "fr"}, "version_group": {"name": "x-y"}}
+			],
+			"pokemon": [
+				{"is_hidden": false, "slot": 1, "pokemon": {"name": "pikachu"}},
+				{"is_hidden": true, "slot": 3, "pokemon": {"name": "electrike"}}
+			]}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	ability, err := client.Ability(context.Background(), "Static")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(ability.Pokemon) != 2 || !ability.Pokemon[1].IsHidden {
+		t.Errorf("unexpected pokemon %+v", ability.Pokemon)
+	}
+	tests := map[string]string{
+		"en": "Has a 30% chance of paralyzing attacking Pokémon on contact.",
+		"fr": "Peut paralyser au contact.",
+		"ja": "",
+	}
+	for language, want := range tests {
+		if got := ability.Effect(language); got != want {
+			t.Errorf("Effect(%q) = %q, want %q", language, got, want)
+		}
+	}
+}
@@ -1,10 +1,8 @@
 package pokeapi
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"net/http"
-	"poke-repl/internal/config"
 )
 
 type PokemonResult struct {
@@ -279,28 +277,16 @@ type PokemonResult struct {
 	} `json:"past_types"`
 }
 
-var Catch PokemonResult
-
-func (p *PokemonResult) CatchPokemon(url string, pokemon string, cfg *config.Config) (*PokemonResult, error) {
-	if cached, ok := pokeCache.Get(pokemon); ok {
-		var cachedResult PokemonResult
-		err := json.Unmarshal(cached, &cachedResult)
-		if err != nil {
-			return nil, fmt.Errorf("error deserializing cached data: %w", err)
-		}
-		return &cachedResult, nil
-	}
-	res, err := http.Get(url + pokemon)
+func (c *Client) CatchPokemon(ctx context.Context, pokemon string) (*PokemonResult, error) {
+	key, url := c.resourceURL("pokemon", pokemon)
+	body, err := c.fetch(ctx, key, url)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("error fetching %s: %s", url, res.Status)
-	}
 	var result PokemonResult
-	err = json.NewDecoder(res.Body).Decode(&result)
+	err = json.Unmarshal(body, &result)
 	if err != nil {
-		return nil, err
+		return nil, &DecodeError{Resource: "pokemon", Err: err}
 	}
 
 	return &result, nil
@@ -1,22 +1,20 @@
 package pokeapi
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
-	"poke-repl/internal/config"
 	"testing"
 )
 
 func TestCatchPokemon(t *testing.T) {
-	cfg := &config.Config{}
-
 	pokemon := "pikachu"
-	expectedURL := "https://pokeapi.co/api/v2/pokemon/" + pokemon
+	expectedPath := "/pokemon/" + pokemon
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.URL.String() != expectedURL {
-			t.Errorf("expected URL %s, got %s", expectedURL, r.URL.String())
+		if r.URL.Path != expectedPath {
+			t.Errorf("expected path %s, got %s", expectedPath, r.URL.Path)
 		}
 
 		response := PokemonResult{
@@ -26,11 +24,12 @@ func TestCatchPokemon(t *testing.T) {
 		json.NewEncoder(w).Encode(response)
 	}))
 	defer server.Close()
-	p := &PokemonResult{}
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
 
-	result, err := p.CatchPokemon("https://pokeapi.co/api/v2/pokemon/", pokemon, cfg)
+	result, err := client.CatchPokemon(context.Background(), pokemon)
 	if err != nil {
-		t.Errorf("unexpected error: %v", err)
+		t.Fatalf("unexpected error: %v", err)
 	}
 
 	if result.Name != "pikachu" {
@@ -38,8 +37,6 @@ func TestCatchPokemon(t *testing.T) {
 	}
 }
 func TestCatchPokemon_CachedData(t *testing.T) {
-	cfg := &config.Config{}
-
 	// Pre-populate the cache
 	pokemonName := "bulbasaur"
 	cachedPokemon := PokemonResult{
@@ -50,7 +47,6 @@ func TestCatchPokemon_CachedData(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to marshal cached data: %v", err)
 	}
-	pokeCache.Set(pokemonName, cachedData)
 
 	// Mock server to ensure no HTTP request is made
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -58,11 +54,14 @@ func TestCatchPokemon_CachedData(t *testing.T) {
 	}))
 	defer server.Close()
 
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	client.cache.Set("pokemon/"+pokemonName, cachedData)
+
 	// Test CatchPokemon with cached data
-	p := &PokemonResult{}
-	result, err := p.CatchPokemon(server.URL, pokemonName, cfg)
+	result, err := client.CatchPokemon(context.Background(), pokemonName)
 	if err != nil {
-		t.Errorf("Unexpected error: %v", err)
+		t.Fatalf("Unexpected error: %v", err)
 	}
 
 	// Verify the result matches the cached data
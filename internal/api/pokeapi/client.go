@@ -0,0 +1,311 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"poke-repl/internal/cache"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	DefaultBaseURL       = "https://pokeapi.co/api/v2"
+	DefaultUserAgent     = "poke-repl"
+	DefaultTimeout       = 10 * time.Second
+	DefaultCacheInterval = 5 * time.Minute
+	DefaultCacheMaxBytes = 64 << 20
+
+	// maxBackgroundRefreshes bounds the stale entries refreshed at once, stale
+	// hits past the bound are served without scheduling a refresh.
+	maxBackgroundRefreshes = 4
+)
+
+var ErrOffline = errors.New("offline mode")
+
+type Client struct {
+	baseURL    string
+	userAgent  string
+	timeout    time.Duration
+	retry      RetryPolicy
+	limiter    *RateLimiter
+	httpClient *http.Client
+	cache      *cache.PokeCache
+	ownsCache  bool
+	flights    flightGroup
+	background sync.WaitGroup
+	refreshes  chan struct{}
+	ctx        context.Context
+	cancel     context.CancelFunc
+	offline    atomic.Bool
+}
+
+type Option func(*Client)
+
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout bounds every attempt at a request, retries and the delays
+// between them are bounded by the caller's context instead.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = policy
+	}
+}
+
+// WithRateLimiter throttles every request made by the client, a nil limiter
+// disables throttling.
+func WithRateLimiter(limiter *RateLimiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithOffline starts the client in offline mode, serving only cached data.
+func WithOffline(offline bool) Option {
+	return func(c *Client) {
+		c.offline.Store(offline)
+	}
+}
+
+func WithCache(pokeCache *cache.PokeCache) Option {
+	return func(c *Client) {
+		c.cache = pokeCache
+	}
+}
+
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		baseURL:    DefaultBaseURL,
+		userAgent:  DefaultUserAgent,
+		timeout:    DefaultTimeout,
+		retry:      DefaultRetryPolicy,
+		limiter:    NewRateLimiter(DefaultRateLimit, DefaultBurst),
+		httpClient: http.DefaultClient,
+		refreshes:  make(chan struct{}, maxBackgroundRefreshes),
+	}
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	for _, opt := range opts {
+		opt(c)
+	}
+	httpClient := *c.httpClient
+	if c.timeout > 0 {
+		httpClient.Transport = &timeoutTransport{next: httpClient.Transport, timeout: c.timeout}
+	}
+	if c.limiter != nil {
+		next := httpClient.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		httpClient.Transport = &rateLimitTransport{next: next, limiter: c.limiter}
+	}
+	if c.retry.MaxAttempts > 1 {
+		httpClient.Transport = newRetryTransport(httpClient.Transport, c.retry)
+	}
+	c.httpClient = &httpClient
+	if c.cache == nil {
+		c.cache = cache.NewCache(DefaultCacheInterval, cache.WithMaxBytes(DefaultCacheMaxBytes))
+		c.ownsCache = true
+	}
+	return c
+}
+
+// Close cancels background refreshes, waits for them to return and releases
+// the cache created by NewClient, a cache passed with WithCache is left to
+// its owner.
+func (c *Client) Close() error {
+	c.cancel()
+	c.background.Wait()
+	if c.ownsCache {
+		return c.cache.Close()
+	}
+	return nil
+}
+
+func (c *Client) BaseURL() string {
+	return c.baseURL
+}
+
+func (c *Client) Cache() *cache.PokeCache {
+	return c.cache
+}
+
+func (c *Client) RateLimiter() *RateLimiter {
+	return c.limiter
+}
+
+func (c *Client) Offline() bool {
+	return c.offline.Load()
+}
+
+func (c *Client) SetOffline(offline bool) {
+	c.offline.Store(offline)
+}
+
+// Available lists the cache keys of resources that can be served without
+// the network, optionally limited to keys starting with prefix.
+func (c *Client) Available(prefix string) []string {
+	return c.cache.Keys(prefix)
+}
+
+func (c *Client) endpoint(parts ...string) string {
+	return c.baseURL + "/" + strings.Join(parts, "/")
+}
+
+type response struct {
+	body        []byte
+	notModified bool
+	validators  cache.Validators
+}
+
+type freshKey struct{}
+
+// withFresh makes fetches under ctx wait for stale entries to be refreshed
+// instead of serving them and refreshing in the background.
+func withFresh(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshKey{}, true)
+}
+
+func wantsFresh(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshKey{}).(bool)
+	return fresh
+}
+
+// fetch returns the body stored under key, hitting the network only when the
+// entry is missing or expired. Expired entries carrying validators are
+// revalidated with a conditional request, and concurrent misses for the same
+// URL share a single request. Stale entries, expired but within their hard
+// TTL, are served right away while they are refreshed in the background,
+// unless ctx comes from withFresh. In offline mode expired entries are served
+// as they are and misses fail with ErrOffline.
+func (c *Client) fetch(ctx context.Context, key, url string) ([]byte, error) {
+	entry, ok := c.cache.Lookup(key)
+	now := c.cache.Now()
+	if ok && (!entry.Expired(now) || c.Offline()) {
+		return entry.Val, nil
+	}
+	if c.Offline() {
+		return nil, fmt.Errorf("%s is not cached: %w", key, ErrOffline)
+	}
+	if ok && entry.Stale(now) && !wantsFresh(ctx) {
+		c.refreshInBackground(key, url)
+		return entry.Val, nil
+	}
+	return c.flights.do(ctx, canonicalURL(url), func(ctx context.Context) ([]byte, error) {
+		return c.refresh(ctx, key, url)
+	})
+}
+
+// refreshInBackground refreshes key unless maxBackgroundRefreshes are already
+// running, in which case a later lookup of the still stale entry retries.
+func (c *Client) refreshInBackground(key, url string) {
+	select {
+	case c.refreshes <- struct{}{}:
+	default:
+		return
+	}
+	c.background.Add(1)
+	go func() {
+		defer c.background.Done()
+		defer func() { <-c.refreshes }()
+		// The refresh runs on c.ctx rather than the detached context
+		// flights hands out, so Close can stop it mid retry.
+		c.flights.do(c.ctx, canonicalURL(url), func(context.Context) ([]byte, error) {
+			return c.refresh(c.ctx, key, url)
+		})
+	}()
+}
+
+func (c *Client) refresh(ctx context.Context, key, url string) ([]byte, error) {
+	entry, ok := c.cache.Peek(key)
+	if ok && !entry.Expired(c.cache.Now()) {
+		return entry.Val, nil
+	}
+	var validators cache.Validators
+	if ok {
+		validators = entry.Validators
+	}
+	res, err := c.request(ctx, url, validators)
+	if err != nil {
+		return nil, err
+	}
+	if res.notModified {
+		c.cache.Refresh(key)
+		return entry.Val, nil
+	}
+	c.cache.SetWithValidators(key, res.body, res.validators)
+	return res.body, nil
+}
+
+func (c *Client) request(ctx context.Context, url string, validators cache.Validators) (response, error) {
+	if c.Offline() {
+		return response{}, fmt.Errorf("cannot fetch %s: %w", url, ErrOffline)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return response{}, err
+	}
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	if validators.ETag != "" {
+		req.Header.Set("If-None-Match", validators.ETag)
+	}
+	if validators.LastModified != "" {
+		req.Header.Set("If-Modified-Since", validators.LastModified)
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		if ctx.Err() != nil {
+			return response{}, err
+		}
+		var retryErr *RetryError
+		if errors.As(err, &retryErr) {
+			return response{}, c.statusError(url, retryErr.StatusCode, retryErr)
+		}
+		return response{}, &UpstreamError{URL: url, Err: err}
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotModified && !validators.Empty() {
+		return response{notModified: true}, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return response{}, c.statusError(url, res.StatusCode, nil)
+	}
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return response{}, err
+	}
+	return response{
+		body: body,
+		validators: cache.Validators{
+			ETag:         res.Header.Get("ETag"),
+			LastModified: res.Header.Get("Last-Modified"),
+		},
+	}, nil
+}
@@ -0,0 +1,278 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"poke-repl/internal/cache"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestNewClient_Defaults(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+	if client.BaseURL() != DefaultBaseURL {
+		t.Errorf("expected base URL %s, got %s", DefaultBaseURL, client.BaseURL())
+	}
+	if client.timeout != DefaultTimeout {
+		t.Errorf("expected timeout %v, got %v", DefaultTimeout, client.timeout)
+	}
+	if client.httpClient.Timeout != 0 {
+		t.Errorf("expected the timeout to apply per attempt, got a client timeout of %v", client.httpClient.Timeout)
+	}
+	if client.cache == nil {
+		t.Errorf("expected a default cache")
+	}
+}
+
+func TestNewClient_Options(t *testing.T) {
+	pokeCache := cache.NewCache(time.Minute)
+	defer pokeCache.Close()
+	httpClient := &http.Client{}
+	client := NewClient(
+		WithBaseURL("http://localhost:8080/api/v2/"),
+		WithHTTPClient(httpClient),
+		WithTimeout(time.Second),
+		WithCache(pokeCache),
+	)
+	if client.BaseURL() != "http://localhost:8080/api/v2" {
+		t.Errorf("expected trailing slash to be trimmed, got %s", client.BaseURL())
+	}
+	if client.timeout != time.Second {
+		t.Errorf("expected timeout %v, got %v", time.Second, client.timeout)
+	}
+	if httpClient.Timeout != 0 {
+		t.Errorf("expected the provided http.Client to be left untouched")
+	}
+	if client.cache != pokeCache {
+		t.Errorf("expected the provided cache to be used")
+	}
+}
+
+func TestClient_UserAgent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.UserAgent() != "poke-test" {
+			t.Errorf("expected user agent poke-test, got %s", r.UserAgent())
+		}
+		w.Write([]byte(`{"id": 1, "name": "bulbasaur"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithUserAgent("poke-test"))
+	defer client.Close()
+	if _, err := client.CatchPokemon(context.Background(), "bulbasaur"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	if _, err := client.CatchPokemon(context.Background(), "bulbasaur"); err == nil {
+		t.Errorf("expected an error, got nil")
+	}
+}
+
+func TestClient_ContextCanceled(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err := client.Explore(ctx, "slow-area")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestClient_Offline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected no HTTP request in offline mode")
+	}))
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Minute, cache.WithTTL(-time.Second))
+	defer pokeCache.Close()
+	client := NewClient(WithBaseURL(server.URL), WithOffline(true), WithCache(pokeCache))
+	defer client.Close()
+	client.cache.Set("pokemon/bulbasaur", []byte(`{"id": 1, "name": "bulbasaur"}`))
+
+	pokemon, err := client.CatchPokemon(context.Background(), "bulbasaur")
+	if err != nil {
+		t.Fatalf("expected expired entries to be served offline, got %v", err)
+	}
+	if pokemon.Name != "bulbasaur" {
+		t.Errorf("expected bulbasaur, got %s", pokemon.Name)
+	}
+
+	_, err = client.CatchPokemon(context.Background(), "pikachu")
+	if !errors.Is(err, ErrOffline) {
+		t.Errorf("expected ErrOffline, got %v", err)
+	}
+
+	available := client.Available("pokemon/")
+	if len(available) != 1 || available[0] != "pokemon/bulbasaur" {
+		t.Errorf("expected only pokemon/bulbasaur to be available, got %v", available)
+	}
+}
+
+func TestClient_CloseLeavesProvidedCache(t *testing.T) {
+	pokeCache := cache.NewCache(time.Minute)
+	defer pokeCache.Close()
+	client := NewClient(WithCache(pokeCache))
+	if err := client.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if client.ownsCache {
+		t.Errorf("expected a provided cache not to be owned by the client")
+	}
+
+	owned := NewClient()
+	if !owned.ownsCache {
+		t.Errorf("expected the default cache to be owned by the client")
+	}
+	if err := owned.Close(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestClient_StaleWhileRevalidate(t *testing.T) {
+	var version atomic.Int32
+	version.Store(1)
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		fmt.Fprintf(w, `{"id": %d, "name": "bulbasaur"}`, version.Load())
+	}))
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Minute, cache.WithTTL(-time.Second), cache.WithHardTTL(time.Hour))
+	defer pokeCache.Close()
+	client := NewClient(WithBaseURL(server.URL), WithCache(pokeCache))
+	defer client.Close()
+
+	pokemon, err := client.CatchPokemon(context.Background(), "bulbasaur")
+	if err != nil || pokemon.ID != 1 {
+		t.Fatalf("unexpected first fetch %+v: %v", pokemon, err)
+	}
+
+	version.Store(2)
+	pokemon, err = client.CatchPokemon(context.Background(), "bulbasaur")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pokemon.ID != 1 {
+		t.Errorf("expected the stale value to be served immediately, got id %d", pokemon.ID)
+	}
+
+	client.background.Wait()
+	if n := calls.Load(); n != 2 {
+		t.Errorf("expected a background refresh, got %d calls", n)
+	}
+	entry, _ := pokeCache.Peek("pokemon/bulbasaur")
+	if !strings.Contains(string(entry.Val), `"id": 2`) {
+		t.Errorf("expected the background refresh to update the cache, got %s", entry.Val)
+	}
+}
+
+func TestClient_BoundsBackgroundRefreshes(t *testing.T) {
+	release := make(chan struct{})
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.Write([]byte(`{"id": 2}`))
+	}))
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Minute, cache.WithTTL(-time.Second), cache.WithHardTTL(time.Hour))
+	defer pokeCache.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRateLimiter(nil), WithCache(pokeCache))
+	defer client.Close()
+
+	names := []string{"bulbasaur", "ivysaur", "venusaur", "charmander", "charmeleon", "charizard"}
+	for _, name := range names {
+		pokeCache.Set("pokemon/"+name, []byte(`{"id": 1}`))
+		pokemon, err := client.CatchPokemon(context.Background(), name)
+		if err != nil || pokemon.ID != 1 {
+			t.Fatalf("expected the stale %s to be served, got %+v: %v", name, pokemon, err)
+		}
+	}
+	close(release)
+	client.background.Wait()
+	if n := calls.Load(); n != maxBackgroundRefreshes {
+		t.Errorf("expected %d background refreshes, got %d", maxBackgroundRefreshes, n)
+	}
+}
+
+func TestClient_CloseStopsBackgroundRefreshes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Minute, cache.WithTTL(-time.Second), cache.WithHardTTL(time.Hour))
+	defer pokeCache.Close()
+	pokeCache.Set("pokemon/bulbasaur", []byte(`{"id": 1}`))
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRateLimiter(nil),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 4, BaseDelay: time.Millisecond, MaxDelay: time.Hour}),
+		WithCache(pokeCache),
+	)
+	if _, err := client.CatchPokemon(context.Background(), "bulbasaur"); err != nil {
+		t.Fatalf("expected the stale entry to be served, got %v", err)
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		client.Close()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("expected Close to cancel the background refresh")
+	}
+}
+
+func TestClient_HardExpiredEntryBlocks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 2, "name": "bulbasaur"}`))
+	}))
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Minute, cache.WithTTL(-2*time.Second), cache.WithHardTTL(-time.Second))
+	defer pokeCache.Close()
+	pokeCache.Set("pokemon/bulbasaur", []byte(`{"id": 1, "name": "bulbasaur"}`))
+	client := NewClient(WithBaseURL(server.URL), WithCache(pokeCache))
+	defer client.Close()
+
+	pokemon, err := client.CatchPokemon(context.Background(), "bulbasaur")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pokemon.ID != 2 {
+		t.Errorf("expected a blocking fetch past the hard TTL, got id %d", pokemon.ID)
+	}
+}
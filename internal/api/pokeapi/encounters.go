@@ -0,0 +1,88 @@
+package pokeapi
+
+import (
+	"slices"
+	"sort"
+)
+
+// EncounterSummary is how a pokemon is met in an area through one method in
+// one game version. Chance sums the chance of every matching encounter slot.
+type EncounterSummary struct {
+	Pokemon  string
+	Method   string
+	MinLevel int
+	MaxLevel int
+	Chance   int
+}
+
+type EncounterRate struct {
+	Method string
+	Rate   int
+}
+
+// Versions lists the game versions the area has encounters in, in the order
+// PokeAPI lists them.
+func (a *LocationAreaResult) Versions() []string {
+	var versions []string
+	for _, encounter := range a.PokemonEncounters {
+		for _, details := range encounter.VersionDetails {
+			if !slices.Contains(versions, details.Version.Name) {
+				versions = append(versions, details.Version.Name)
+			}
+		}
+	}
+	return versions
+}
+
+// Encounters summarizes the encounters of the area in version, sorted by
+// pokemon then method.
+func (a *LocationAreaResult) Encounters(version string) []EncounterSummary {
+	var summaries []EncounterSummary
+	for _, encounter := range a.PokemonEncounters {
+		byMethod := make(map[string]*EncounterSummary)
+		for _, details := range encounter.VersionDetails {
+			if details.Version.Name != version {
+				continue
+			}
+			for _, detail := range details.EncounterDetails {
+				summary, ok := byMethod[detail.Method.Name]
+				if !ok {
+					summary = &EncounterSummary{
+						Pokemon:  encounter.Pokemon.Name,
+						Method:   detail.Method.Name,
+						MinLevel: detail.MinLevel,
+						MaxLevel: detail.MaxLevel,
+					}
+					byMethod[detail.Method.Name] = summary
+				}
+				summary.MinLevel = min(summary.MinLevel, detail.MinLevel)
+				summary.MaxLevel = max(summary.MaxLevel, detail.MaxLevel)
+				summary.Chance += detail.Chance
+			}
+		}
+		for _, summary := range byMethod {
+			summaries = append(summaries, *summary)
+		}
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Pokemon != summaries[j].Pokemon {
+			return summaries[i].Pokemon < summaries[j].Pokemon
+		}
+		return summaries[i].Method < summaries[j].Method
+	})
+	return summaries
+}
+
+// EncounterRates returns the rate of every encounter method of the area in
+// version, in the order PokeAPI lists them.
+func (a *LocationAreaResult) EncounterRates(version string) []EncounterRate {
+	var rates []EncounterRate
+	for _, method := range a.EncounterMethodRates {
+		for _, details := range method.VersionDetails {
+			if details.Version.Name == version {
+				rates = append(rates, EncounterRate{Method: method.EncounterMethod.Name, Rate: details.Rate})
+			}
+		}
+	}
+	return rates
+}
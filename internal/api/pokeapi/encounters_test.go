@@ -0,0 +1,76 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+const viridianForestArea = `{"id": 321, "name": "viridian-forest-area",
+	"encounter_method_rates": [
+		{"encounter_method": {"name": "walk"}, "version_details": [{"rate": 8, "version": {"name": "red"}}, {"rate": 4, "version": {"name": "yellow"}}]},
+		{"encounter_method": {"name": "old-rod"}, "version_details": [{"rate": 25, "version": {"name": "yellow"}}]}
+	],
+	"pokemon_encounters": [
+		{"pokemon": {"name": "pikachu"}, "version_details": [
+			{"version": {"name": "red"}, "max_chance": 5, "encounter_details": [
+				{"min_level": 3, "max_level": 3, "chance": 5, "method": {"name": "walk"}}
+			]},
+			{"version": {"name": "yellow"}, "max_chance": 10, "encounter_details": [
+				{"min_level": 3, "max_level": 3, "chance": 5, "method": {"name": "walk"}},
+				{"min_level": 5, "max_level": 5, "chance": 5, "method": {"name": "walk"}}
+			]}
+		]},
+		{"pokemon": {"name": "caterpie"}, "version_details": [
+			{"version": {"name": "red"}, "max_chance": 50, "encounter_details": [
+				{"min_level": 3, "max_level": 3, "chance": 20, "method": {"name": "walk"}},
+				{"min_level": 5, "max_level": 5, "chance": 30, "method": {"name": "walk"}}
+			]}
+		]},
+		{"pokemon": {"name": "magikarp"}, "version_details": [
+			{"version": {"name": "yellow"}, "max_chance": 100, "encounter_details": [
+				{"min_level": 5, "max_level": 5, "chance": 100, "method": {"name": "old-rod"}}
+			]}
+		]}
+	]}`
+
+func TestLocationArea_Encounters(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(viridianForestArea))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	area, err := client.LocationArea(context.Background(), "viridian-forest-area")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if versions := area.Versions(); !reflect.DeepEqual(versions, []string{"red", "yellow"}) {
+		t.Errorf("unexpected versions %v", versions)
+	}
+
+	tests := map[string][]EncounterSummary{
+		"red": {
+			{Pokemon: "caterpie", Method: "walk", MinLevel: 3, MaxLevel: 5, Chance: 50},
+			{Pokemon: "pikachu", Method: "walk", MinLevel: 3, MaxLevel: 3, Chance: 5},
+		},
+		"yellow": {
+			{Pokemon: "magikarp", Method: "old-rod", MinLevel: 5, MaxLevel: 5, Chance: 100},
+			{Pokemon: "pikachu", Method: "walk", MinLevel: 3, MaxLevel: 5, Chance: 10},
+		},
+		"blue": nil,
+	}
+	for version, want := range tests {
+		if got := area.Encounters(version); !reflect.DeepEqual(got, want) {
+			t.Errorf("Encounters(%q) = %+v, want %+v", version, got, want)
+		}
+	}
+
+	want := []EncounterRate{{Method: "walk", Rate: 4}, {Method: "old-rod", Rate: 25}}
+	if rates := area.EncounterRates("yellow"); !reflect.DeepEqual(rates, want) {
+		t.Errorf("expected %+v, got %+v", want, rates)
+	}
+}
@@ -0,0 +1,110 @@
+package pokeapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrRateLimited = errors.New("rate limited")
+	ErrUpstream    = errors.New("upstream error")
+	ErrDecode      = errors.New("decode error")
+)
+
+// NotFoundError is returned when PokeAPI has no resource under the requested
+// name, Resource and Name are empty for URLs outside the client's base URL.
+type NotFoundError struct {
+	Resource string
+	Name     string
+	URL      string
+}
+
+func (e *NotFoundError) Error() string {
+	if e.Resource == "" || e.Name == "" {
+		return fmt.Sprintf("%s not found", e.URL)
+	}
+	return fmt.Sprintf("%s %s not found", e.Resource, e.Name)
+}
+
+func (e *NotFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// RateLimitedError is returned when PokeAPI answers 429, Err holds the
+// RetryError once every retry was spent.
+type RateLimitedError struct {
+	URL string
+	Err error
+}
+
+func (e *RateLimitedError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("error fetching %s: %d %s", e.URL, http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests))
+}
+
+func (e *RateLimitedError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+func (e *RateLimitedError) Unwrap() error {
+	return e.Err
+}
+
+// UpstreamError is returned when PokeAPI is unreachable or fails with a
+// status other than 404 and 429. StatusCode is zero for network errors.
+type UpstreamError struct {
+	URL        string
+	StatusCode int
+	Err        error
+}
+
+func (e *UpstreamError) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("error fetching %s: %d %s", e.URL, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *UpstreamError) Is(target error) bool {
+	return target == ErrUpstream
+}
+
+func (e *UpstreamError) Unwrap() error {
+	return e.Err
+}
+
+// DecodeError is returned when a response body is not the expected JSON.
+type DecodeError struct {
+	Resource string
+	Err      error
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("error deserializing %s: %v", e.Resource, e.Err)
+}
+
+func (e *DecodeError) Is(target error) bool {
+	return target == ErrDecode
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// statusError maps a failed response for url to its typed error, err is the
+// RetryError when the response came out of the retry transport.
+func (c *Client) statusError(url string, statusCode int, err error) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		resource, name, _, _ := c.parseResourceURL(url)
+		return &NotFoundError{Resource: resource, Name: name, URL: url}
+	case statusCode == http.StatusTooManyRequests:
+		return &RateLimitedError{URL: url, Err: err}
+	default:
+		return &UpstreamError{URL: url, StatusCode: statusCode, Err: err}
+	}
+}
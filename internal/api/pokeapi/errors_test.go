@@ -0,0 +1,102 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_TypedErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   error
+	}{
+		{name: "not found", status: http.StatusNotFound, want: ErrNotFound},
+		{name: "rate limited", status: http.StatusTooManyRequests, want: ErrRateLimited},
+		{name: "server error", status: http.StatusServiceUnavailable, want: ErrUpstream},
+		{name: "unexpected status", status: http.StatusForbidden, want: ErrUpstream},
+		{name: "invalid json", status: http.StatusOK, body: "<html>", want: ErrDecode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+			client := NewClient(WithBaseURL(server.URL), WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+			defer client.Close()
+
+			_, err := client.CatchPokemon(context.Background(), "pikachuu")
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			for _, other := range []error{ErrNotFound, ErrRateLimited, ErrUpstream, ErrDecode} {
+				if other != tt.want && errors.Is(err, other) {
+					t.Errorf("expected %v not to match %v", err, other)
+				}
+			}
+		})
+	}
+}
+
+func TestClient_NotFoundError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	_, err := client.Explore(context.Background(), "Nowhere-Area")
+	var notFound *NotFoundError
+	if !errors.As(err, &notFound) {
+		t.Fatalf("expected a NotFoundError, got %v", err)
+	}
+	if notFound.Resource != "location-area" || notFound.Name != "nowhere-area" {
+		t.Errorf("unexpected resource %q and name %q", notFound.Resource, notFound.Name)
+	}
+	if err.Error() != "location-area nowhere-area not found" {
+		t.Errorf("unexpected message %q", err)
+	}
+}
+
+func TestClient_RateLimitedAfterRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+	)
+	defer client.Close()
+
+	_, err := client.CatchPokemon(context.Background(), "pikachu")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected a rate limited error, got %v", err)
+	}
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) || retryErr.Attempts != 2 {
+		t.Errorf("expected the RetryError to be kept, got %v", err)
+	}
+}
+
+func TestClient_CanceledRequestIsNotUpstream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 25, "name": "pikachu"}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.CatchPokemon(ctx, "pikachu")
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrUpstream) {
+		t.Errorf("expected a plain cancellation, got %v", err)
+	}
+}
@@ -0,0 +1,122 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type EvolutionChainResult struct {
+	ID              int `json:"id"`
+	BabyTriggerItem struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"baby_trigger_item"`
+	Chain ChainLink `json:"chain"`
+}
+
+// ChainLink is a species in an evolution chain, EvolutionDetails describe
+// how it evolves from its parent link and EvolvesTo holds its evolutions.
+type ChainLink struct {
+	IsBaby  bool `json:"is_baby"`
+	Species struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"species"`
+	EvolutionDetails []EvolutionDetail `json:"evolution_details"`
+	EvolvesTo        []ChainLink       `json:"evolves_to"`
+}
+
+type EvolutionDetail struct {
+	Trigger struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"trigger"`
+	Item struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"item"`
+	HeldItem struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"held_item"`
+	KnownMove struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"known_move"`
+	Location struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location"`
+	MinLevel     int    `json:"min_level"`
+	MinHappiness int    `json:"min_happiness"`
+	MinAffection int    `json:"min_affection"`
+	TimeOfDay    string `json:"time_of_day"`
+}
+
+// String describes the conditions of the evolution, such as "level 16" or
+// "use thunder-stone".
+func (d EvolutionDetail) String() string {
+	var conditions []string
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel > 0 {
+			conditions = append(conditions, fmt.Sprintf("level %d", d.MinLevel))
+		} else {
+			conditions = append(conditions, "level up")
+		}
+	case "use-item":
+		conditions = append(conditions, "use "+d.Item.Name)
+	case "":
+	default:
+		conditions = append(conditions, d.Trigger.Name)
+	}
+	if d.Item.Name != "" && d.Trigger.Name != "use-item" {
+		conditions = append(conditions, "with "+d.Item.Name)
+	}
+	if d.HeldItem.Name != "" {
+		conditions = append(conditions, "holding "+d.HeldItem.Name)
+	}
+	if d.KnownMove.Name != "" {
+		conditions = append(conditions, "knowing "+d.KnownMove.Name)
+	}
+	if d.Location.Name != "" {
+		conditions = append(conditions, "at "+d.Location.Name)
+	}
+	if d.MinHappiness > 0 {
+		conditions = append(conditions, fmt.Sprintf("happiness %d", d.MinHappiness))
+	}
+	if d.MinAffection > 0 {
+		conditions = append(conditions, fmt.Sprintf("affection %d", d.MinAffection))
+	}
+	if d.TimeOfDay != "" {
+		conditions = append(conditions, "during the "+d.TimeOfDay)
+	}
+	return strings.Join(conditions, ", ")
+}
+
+// EvolutionChainID returns the id of the evolution chain the species belongs
+// to, taken from the last segment of its URL.
+func (s *SpeciesResult) EvolutionChainID() (int, bool) {
+	id, err := strconv.Atoi(lastPathSegment(s.EvolutionChain.URL))
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+func (c *Client) EvolutionChain(ctx context.Context, id int) (*EvolutionChainResult, error) {
+	key, url := c.resourceURL("evolution-chain", strconv.Itoa(id))
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result EvolutionChainResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "evolution chain", Err: err}
+	}
+	return &result, nil
+}
@@ -0,0 +1,83 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const oddishChain = `{"id": 18, "baby_trigger_item": null, "chain": {
+	"is_baby": false, "species": {"name": "oddish"}, "evolution_details": [],
+	"evolves_to": [{
+		"species": {"name": "gloom"},
+		"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 21, "item": null, "time_of_day": ""}],
+		"evolves_to": [
+			{"species": {"name": "vileplume"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "leaf-stone"}}], "evolves_to": []},
+			{"species": {"name": "bellossom"}, "evolution_details": [{"trigger": {"name": "use-item"}, "item": {"name": "sun-stone"}}], "evolves_to": []}
+		]
+	}]
+}}`
+
+func TestEvolutionChain(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/evolution-chain/18" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(oddishChain))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	chain, err := client.EvolutionChain(context.Background(), 18)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	root := chain.Chain
+	if root.Species.Name != "oddish" || len(root.EvolvesTo) != 1 {
+		t.Fatalf("unexpected root %+v", root)
+	}
+	gloom := root.EvolvesTo[0]
+	if len(gloom.EvolvesTo) != 2 || gloom.EvolvesTo[1].Species.Name != "bellossom" {
+		t.Errorf("expected gloom to branch into vileplume and bellossom, got %+v", gloom.EvolvesTo)
+	}
+	if got := gloom.EvolutionDetails[0].String(); got != "level 21" {
+		t.Errorf("unexpected gloom evolution %q", got)
+	}
+}
+
+func TestEvolutionDetail_String(t *testing.T) {
+	detail := func(raw string) EvolutionDetail {
+		var d EvolutionDetail
+		if err := json.Unmarshal([]byte(raw), &d); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return d
+	}
+	tests := map[string]string{
+		`{"trigger": {"name": "level-up"}, "min_level": 16}`:                            "level 16",
+		`{"trigger": {"name": "use-item"}, "item": {"name": "thunder-stone"}}`:          "use thunder-stone",
+		`{"trigger": {"name": "level-up"}, "min_happiness": 220, "time_of_day": "day"}`: "level up, happiness 220, during the day",
+		`{"trigger": {"name": "trade"}, "held_item": {"name": "metal-coat"}}`:           "trade, holding metal-coat",
+		`{"trigger": {"name": "level-up"}, "known_move": {"name": "ancient-power"}}`:    "level up, knowing ancient-power",
+	}
+	for in, want := range tests {
+		if got := detail(in).String(); got != want {
+			t.Errorf("String() of %s = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSpecies_EvolutionChainID(t *testing.T) {
+	species := SpeciesResult{}
+	species.EvolutionChain.URL = "https://pokeapi.co/api/v2/evolution-chain/10/"
+	if id, ok := species.EvolutionChainID(); !ok || id != 10 {
+		t.Errorf("expected chain 10, got %d", id)
+	}
+	species.EvolutionChain.URL = ""
+	if _, ok := species.EvolutionChainID(); ok {
+		t.Errorf("expected no chain without a URL")
+	}
+}
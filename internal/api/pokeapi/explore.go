@@ -1,15 +1,11 @@
 package pokeapi
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"net/http"
-	"poke-repl/internal/config"
 	"sort"
 )
 
-var Explorer LocationAreaResult
-
 type LocationAreaResult struct {
 	ID                   int    `json:"id"`
 	Name                 string `json:"name"`
@@ -63,24 +59,24 @@ type LocationAreaResult struct {
 	} `json:"pokemon_encounters"`
 }
 
-func (l *LocationAreaResult) Explore(url string, area string, cfg *config.Config) ([]string, error) {
-	if cached, ok := pokeCache.Get(area); ok {
-		var cachedPokemons []string
-		err := json.Unmarshal(cached, &cachedPokemons)
-		if err != nil {
-			return nil, fmt.Errorf("error deserializing cached data: %w", err)
-		}
-		return cachedPokemons, nil
-	}
-	res, err := http.Get(url + area)
+// LocationArea returns an area with its encounters, Explore only keeps the
+// names of the pokemon.
+func (c *Client) LocationArea(ctx context.Context, area string) (*LocationAreaResult, error) {
+	key, url := c.resourceURL("location-area", area)
+	body, err := c.fetch(ctx, key, url)
 	if err != nil {
 		return nil, err
 	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("error fetching %s: %s", url, res.Status)
-	}
 	var result LocationAreaResult
-	err = json.NewDecoder(res.Body).Decode(&result)
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "location area", Err: err}
+	}
+	return &result, nil
+}
+
+func (c *Client) Explore(ctx context.Context, area string) ([]string, error) {
+	result, err := c.LocationArea(ctx, area)
 	if err != nil {
 		return nil, err
 	}
@@ -89,10 +85,5 @@ func (l *LocationAreaResult) Explore(url string, area string, cfg *config.Config
 		pokemons = append(pokemons, pokemon.Pokemon.Name)
 	}
 	sort.Strings(pokemons)
-	pokemonsData, err := json.Marshal(pokemons)
-	if err != nil {
-		return nil, err
-	}
-	pokeCache.Set(area, pokemonsData)
 	return pokemons, nil
 }
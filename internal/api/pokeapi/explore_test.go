@@ -1,9 +1,9 @@
 package pokeapi
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
-	"poke-repl/internal/config"
 	"reflect"
 	"sort"
 	"testing"
@@ -11,6 +11,9 @@ import (
 
 func TestLocationAreaResult_Explore(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/location-area/area" {
+			t.Errorf("expected path /location-area/area, got %s", r.URL.Path)
+		}
 		w.WriteHeader(http.StatusOK)
 		responseBody := `{
 			"id": 1,
@@ -113,9 +116,9 @@ func TestLocationAreaResult_Explore(t *testing.T) {
 	}))
 	defer server.Close()
 
-	mockConfig := &config.Config{}
-	locationAreaResult := &LocationAreaResult{}
-	pokemons, err := locationAreaResult.Explore(server.URL, "/area", mockConfig)
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	pokemons, err := client.Explore(context.Background(), "area")
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -135,19 +138,19 @@ func TestLocationAreaResult_Explore_DecoderError(t *testing.T) {
 	}))
 	defer server.Close()
 
-	mockConfig := &config.Config{}
-	locationAreaResult := &LocationAreaResult{}
-	_, err := locationAreaResult.Explore(server.URL, "area", mockConfig)
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	_, err := client.Explore(context.Background(), "area")
 	if err == nil {
 		t.Error("Expected a JSON decoder error, got nil")
 	}
 }
 
 func TestLocationAreaResult_Explore_CachedData(t *testing.T) {
-	mockConfig := &config.Config{}
-	locationAreaResult := &LocationAreaResult{}
-	pokeCache.Set("area", []byte(`["Cached Pokemon 1", "Cached Pokemon 2"]`))
-	pokemons, err := locationAreaResult.Explore("https://pokeapi.co/api/v2/", "area", mockConfig)
+	client := NewClient()
+	defer client.Close()
+	client.cache.Set("location-area/area", []byte(`{"name": "area", "pokemon_encounters": [{"pokemon": {"name": "Cached Pokemon 2"}}, {"pokemon": {"name": "Cached Pokemon 1"}}]}`))
+	pokemons, err := client.Explore(context.Background(), "area")
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
 	}
@@ -0,0 +1,92 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+type ItemResult struct {
+	ID            int             `json:"id"`
+	Name          string          `json:"name"`
+	Cost          int             `json:"cost"`
+	FlingPower    int             `json:"fling_power"`
+	FlingEffect   NamedResource   `json:"fling_effect"`
+	Category      NamedResource   `json:"category"`
+	Attributes    []NamedResource `json:"attributes"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+	FlavorTextEntries []struct {
+		Text         string        `json:"text"`
+		Language     NamedResource `json:"language"`
+		VersionGroup NamedResource `json:"version_group"`
+	} `json:"flavor_text_entries"`
+	HeldByPokemon []struct {
+		Pokemon NamedResource `json:"pokemon"`
+	} `json:"held_by_pokemon"`
+}
+
+// Effect returns the short effect text of the item in language, falling
+// back to the flavor text of the most recent game like AbilityResult.Effect.
+func (i *ItemResult) Effect(language string) string {
+	for _, entry := range i.EffectEntries {
+		if entry.Language.Name == language {
+			return strings.Join(strings.Fields(entry.ShortEffect), " ")
+		}
+	}
+	for j := len(i.FlavorTextEntries) - 1; j >= 0; j-- {
+		if entry := i.FlavorTextEntries[j]; entry.Language.Name == language {
+			return strings.Join(strings.Fields(entry.Text), " ")
+		}
+	}
+	return ""
+}
+
+type BerryResult struct {
+	ID               int           `json:"id"`
+	Name             string        `json:"name"`
+	GrowthTime       int           `json:"growth_time"`
+	MaxHarvest       int           `json:"max_harvest"`
+	NaturalGiftPower int           `json:"natural_gift_power"`
+	Size             int           `json:"size"`
+	Smoothness       int           `json:"smoothness"`
+	SoilDryness      int           `json:"soil_dryness"`
+	Firmness         NamedResource `json:"firmness"`
+	NaturalGiftType  NamedResource `json:"natural_gift_type"`
+	Item             NamedResource `json:"item"`
+	Flavors          []struct {
+		Potency int           `json:"potency"`
+		Flavor  NamedResource `json:"flavor"`
+	} `json:"flavors"`
+}
+
+func (c *Client) Item(ctx context.Context, name string) (*ItemResult, error) {
+	key, url := c.resourceURL("item", name)
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result ItemResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "item", Err: err}
+	}
+	return &result, nil
+}
+
+func (c *Client) Berry(ctx context.Context, name string) (*BerryResult, error) {
+	key, url := c.resourceURL("berry", name)
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result BerryResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "berry", Err: err}
+	}
+	return &result, nil
+}
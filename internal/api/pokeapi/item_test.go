@@ -0,0 +1,63 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestItem(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/item/light-ball" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id": 213, "name": "light-ball", "cost": 1000, "fling_power": 30,
+			"fling_effect": {"name": "paralyze"}, "category": {"name": "species-specific"},
+			"attributes": [{"name": "holdable"}, {"name": "holdable-active"}],
+			"effect_entries": [{"short_effect": "Doubles Pikachu's Attack and\nSpecial Attack.", "language": {"name": "en"}}],
+			"flavor_text_entries": [{"text": "Une orbe à faire\ntenir à Pikachu.", "language": {"name": "fr"}, "version_group": {"name": "x-y"}}]}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	item, err := client.Item(context.Background(), "light-ball")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if item.Cost != 1000 || item.FlingPower != 30 || item.Category.Name != "species-specific" || len(item.Attributes) != 2 {
+		t.Errorf("unexpected item %+v", item)
+	}
+	if effect := item.Effect("en"); effect != "Doubles Pikachu's Attack and Special Attack." {
+		t.Errorf("unexpected effect %q", effect)
+	}
+	if effect := item.Effect("fr"); effect != "Une orbe à faire tenir à Pikachu." {
+		t.Errorf("unexpected french effect %q", effect)
+	}
+}
+
+func TestBerry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/berry/cheri" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"id": 1, "name": "cheri", "growth_time": 3, "max_harvest": 5, "natural_gift_power": 60,
+			"firmness": {"name": "soft"}, "natural_gift_type": {"name": "fire"}, "item": {"name": "cheri-berry"},
+			"flavors": [{"potency": 10, "flavor": {"name": "spicy"}}, {"potency": 0, "flavor": {"name": "dry"}}]}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	berry, err := client.Berry(context.Background(), "Cheri")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if berry.GrowthTime != 3 || berry.Firmness.Name != "soft" || berry.Item.Name != "cheri-berry" {
+		t.Errorf("unexpected berry %+v", berry)
+	}
+	if len(berry.Flavors) != 2 || berry.Flavors[0].Flavor.Name != "spicy" || berry.Flavors[0].Potency != 10 {
+		t.Errorf("unexpected flavors %+v", berry.Flavors)
+	}
+}
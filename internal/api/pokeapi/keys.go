@@ -0,0 +1,61 @@
+package pokeapi
+
+import (
+	"net/url"
+	"strings"
+)
+
+// cacheKey builds the key a resource is cached under: "<resource>/<name>" for
+// a single resource and "<resource>?<query>" for a page of a resource list,
+// with the query parameters sorted.
+func cacheKey(resource, name string, query url.Values) string {
+	key := resource
+	if name != "" {
+		key += "/" + name
+	}
+	if len(query) > 0 {
+		key += "?" + query.Encode()
+	}
+	return key
+}
+
+// keyForURL maps a URL returned by PokeAPI, such as a "next" link, to its
+// cache key. URLs outside the client's base URL are keyed by their canonical
+// form.
+func (c *Client) keyForURL(rawURL string) string {
+	resource, name, query, ok := c.parseResourceURL(rawURL)
+	if !ok {
+		return canonicalURL(rawURL)
+	}
+	return cacheKey(resource, name, query)
+}
+
+// parseResourceURL splits a URL under the client's base URL into its
+// resource, name and query, ok is false for any other URL.
+func (c *Client) parseResourceURL(rawURL string) (resource, name string, query url.Values, ok bool) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", "", nil, false
+	}
+	base, err := url.Parse(c.baseURL)
+	if err != nil || !strings.EqualFold(u.Host, base.Host) || !strings.HasPrefix(u.Path, base.Path+"/") {
+		return "", "", nil, false
+	}
+	parts := strings.SplitN(strings.Trim(strings.TrimPrefix(u.Path, base.Path), "/"), "/", 2)
+	if len(parts) == 2 {
+		name = parts[1]
+	}
+	return parts[0], name, u.Query(), true
+}
+
+func (c *Client) resourceURL(resource, name string) (string, string) {
+	name = strings.ToLower(name)
+	return cacheKey(resource, name, nil), c.endpoint(resource, url.PathEscape(name))
+}
+
+// lastPathSegment returns the last segment of a resource URL, such as "10"
+// for ".../evolution-chain/10/".
+func lastPathSegment(rawURL string) string {
+	trimmed := strings.TrimRight(rawURL, "/")
+	return trimmed[strings.LastIndex(trimmed, "/")+1:]
+}
@@ -0,0 +1,58 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestKeyForURL(t *testing.T) {
+	client := NewClient(WithBaseURL("https://pokeapi.co/api/v2"))
+	defer client.Close()
+	cases := map[string]string{
+		"https://pokeapi.co/api/v2/location-area?offset=20&limit=20":  "location-area?limit=20&offset=20",
+		"https://pokeapi.co/api/v2/location-area/canalave-city-area/": "location-area/canalave-city-area",
+		"https://pokeapi.co/api/v2/pokemon/25/":                       "pokemon/25",
+		"https://mirror.example.com/api/v2/pokemon/25/":               "https://mirror.example.com/api/v2/pokemon/25",
+	}
+	for in, want := range cases {
+		if got := client.keyForURL(in); got != want {
+			t.Errorf("keyForURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCacheKeys_DoNotCollideAcrossResources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/pokemon/shared":
+			w.Write([]byte(`{"id": 1, "name": "shared"}`))
+		case "/location-area/shared":
+			w.Write([]byte(`{"id": 2, "name": "shared", "pokemon_encounters": [{"pokemon": {"name": "bulbasaur"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	pokemons, err := client.Explore(context.Background(), "shared")
+	if err != nil || len(pokemons) != 1 {
+		t.Fatalf("unexpected explore result %v: %v", pokemons, err)
+	}
+	pokemon, err := client.CatchPokemon(context.Background(), "shared")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pokemon.ID != 1 {
+		t.Errorf("expected the pokemon to be fetched separately, got id %d", pokemon.ID)
+	}
+
+	for _, key := range []string{"pokemon/shared", "location-area/shared"} {
+		if _, ok := client.cache.Get(key); !ok {
+			t.Errorf("expected raw JSON to be cached under %s", key)
+		}
+	}
+}
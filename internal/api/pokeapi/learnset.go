@@ -0,0 +1,73 @@
+package pokeapi
+
+import (
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// LearnMethods are the usual ways a pokemon learns a move, in the order a
+// learnset lists them.
+var LearnMethods = []string{"level-up", "machine", "egg", "tutor"}
+
+type LearnsetEntry struct {
+	Move   string
+	Method string
+	Level  int
+}
+
+// Learnset lists the moves the pokemon learns in a version group, optionally
+// only through method. Entries are sorted by method, then level, then name.
+func (p *PokemonResult) Learnset(versionGroup, method string) []LearnsetEntry {
+	var entries []LearnsetEntry
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != versionGroup || (method != "" && detail.MoveLearnMethod.Name != method) {
+				continue
+			}
+			entries = append(entries, LearnsetEntry{
+				Move:   move.Move.Name,
+				Method: detail.MoveLearnMethod.Name,
+				Level:  detail.LevelLearnedAt,
+			})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.Method != b.Method {
+			return methodRank(a.Method) < methodRank(b.Method) ||
+				methodRank(a.Method) == methodRank(b.Method) && a.Method < b.Method
+		}
+		if a.Level != b.Level {
+			return a.Level < b.Level
+		}
+		return a.Move < b.Move
+	})
+	return entries
+}
+
+// LatestVersionGroup returns the most recent version group the pokemon
+// learns moves in, the one with the highest id in its URL. Without URLs the
+// last version group listed is returned.
+func (p *PokemonResult) LatestVersionGroup() string {
+	latest, latestID := "", -1
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			id, err := strconv.Atoi(lastPathSegment(detail.VersionGroup.URL))
+			if err != nil {
+				id = 0
+			}
+			if id >= latestID {
+				latest, latestID = detail.VersionGroup.Name, id
+			}
+		}
+	}
+	return latest
+}
+
+func methodRank(method string) int {
+	if i := slices.Index(LearnMethods, method); i >= 0 {
+		return i
+	}
+	return len(LearnMethods)
+}
@@ -0,0 +1,94 @@
+package pokeapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const pikachuMoves = `{"name": "pikachu", "moves": [
+	{"move": {"name": "thunder-shock"}, "version_group_details": [
+		{"level_learned_at": 1, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}, "move_learn_method": {"name": "level-up"}},
+		{"level_learned_at": 1, "version_group": {"name": "yellow", "url": "https://pokeapi.co/api/v2/version-group/2/"}, "move_learn_method": {"name": "level-up"}}
+	]},
+	{"move": {"name": "thunderbolt"}, "version_group_details": [
+		{"level_learned_at": 0, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}, "move_learn_method": {"name": "machine"}},
+		{"level_learned_at": 0, "version_group": {"name": "yellow", "url": "https://pokeapi.co/api/v2/version-group/2/"}, "move_learn_method": {"name": "machine"}}
+	]},
+	{"move": {"name": "growl"}, "version_group_details": [
+		{"level_learned_at": 1, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}, "move_learn_method": {"name": "level-up"}},
+		{"level_learned_at": 5, "version_group": {"name": "yellow", "url": "https://pokeapi.co/api/v2/version-group/2/"}, "move_learn_method": {"name": "level-up"}}
+	]},
+	{"move": {"name": "thunder-wave"}, "version_group_details": [
+		{"level_learned_at": 9, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}, "move_learn_method": {"name": "level-up"}},
+		{"level_learned_at": 0, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}, "move_learn_method": {"name": "machine"}}
+	]},
+	{"move": {"name": "surf"}, "version_group_details": [
+		{"level_learned_at": 0, "version_group": {"name": "yellow", "url": "https://pokeapi.co/api/v2/version-group/2/"}, "move_learn_method": {"name": "stadium-surfing-pikachu"}}
+	]}
+]}`
+
+func TestLearnset(t *testing.T) {
+	var pokemon PokemonResult
+	if err := json.Unmarshal([]byte(pikachuMoves), &pokemon); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		versionGroup, method string
+		want                 []LearnsetEntry
+	}{
+		{"red-blue", "", []LearnsetEntry{
+			{Move: "growl", Method: "level-up", Level: 1},
+			{Move: "thunder-shock", Method: "level-up", Level: 1},
+			{Move: "thunder-wave", Method: "level-up", Level: 9},
+			{Move: "thunder-wave", Method: "machine"},
+			{Move: "thunderbolt", Method: "machine"},
+		}},
+		{"yellow", "", []LearnsetEntry{
+			{Move: "thunder-shock", Method: "level-up", Level: 1},
+			{Move: "growl", Method: "level-up", Level: 5},
+			{Move: "thunderbolt", Method: "machine"},
+			{Move: "surf", Method: "stadium-surfing-pikachu"},
+		}},
+		{"red-blue", "machine", []LearnsetEntry{
+			{Move: "thunder-wave", Method: "machine"},
+			{Move: "thunderbolt", Method: "machine"},
+		}},
+		{"gold-silver", "", nil},
+	}
+	for _, tt := range tests {
+		if got := pokemon.Learnset(tt.versionGroup, tt.method); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Learnset(%q, %q) = %+v, want %+v", tt.versionGroup, tt.method, got, tt.want)
+		}
+	}
+}
+
+func TestLatestVersionGroup(t *testing.T) {
+	var pokemon PokemonResult
+	if err := json.Unmarshal([]byte(pikachuMoves), &pokemon); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := pokemon.LatestVersionGroup(); got != "yellow" {
+		t.Errorf("expected yellow, got %q", got)
+	}
+	var later PokemonResult
+	err := json.Unmarshal([]byte(`{"moves": [
+		{"move": {"name": "tackle"}, "version_group_details": [
+			{"version_group": {"name": "sun-moon", "url": "https://pokeapi.co/api/v2/version-group/17/"}},
+			{"version_group": {"name": "x-y", "url": "https://pokeapi.co/api/v2/version-group/15/"}}
+		]},
+		{"move": {"name": "growl"}, "version_group_details": [
+			{"version_group": {"name": "x-y", "url": "https://pokeapi.co/api/v2/version-group/15/"}}
+		]}
+	]}`), &later)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := later.LatestVersionGroup(); got != "sun-moon" {
+		t.Errorf("expected the highest version group id to win, got %q", got)
+	}
+	if got := (&PokemonResult{}).LatestVersionGroup(); got != "" {
+		t.Errorf("expected no version group without moves, got %q", got)
+	}
+}
@@ -1,14 +1,11 @@
 package pokeapi
 
 import (
+	"context"
 	"encoding/json"
-	"fmt"
-	"net/http"
 	"poke-repl/internal/config"
 )
 
-var Location LocationResult
-
 type LocationList []LocationInfo
 type LocationInfo struct {
 	Name string `json:"name"`
@@ -24,32 +21,8 @@ type LocationResult struct {
 	} `json:"results"`
 }
 
-func (l *LocationResult) GetLocation(url string, cfg *config.Config) (LocationList, error) {
-	if cached, ok := pokeCache.Get(url); ok {
-		var cachedResult LocationResult
-		err := json.Unmarshal(cached, &cachedResult)
-		if err != nil {
-			return nil, fmt.Errorf("error deserializing cached data: %w", err)
-		}
-		cfg.NextUrl = cachedResult.Next
-		if prevUrl, ok := cachedResult.Previous.(string); ok {
-			cfg.PreviousUrl = prevUrl
-		}
-		var locations LocationList
-		for _, item := range cachedResult.Results {
-			locations = append(locations, LocationInfo{Name: item.Name, URL: item.URL})
-		}
-		return locations, nil
-	}
-	res, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("error fetching %s: %s", url, res.Status)
-	}
-	var result LocationResult
-	err = json.NewDecoder(res.Body).Decode(&result)
+func (c *Client) GetLocation(ctx context.Context, url string, cfg *config.Config) (LocationList, error) {
+	result, err := c.locationPage(ctx, url)
 	if err != nil {
 		return nil, err
 	}
@@ -57,11 +30,6 @@ func (l *LocationResult) GetLocation(url string, cfg *config.Config) (LocationLi
 	if prevUrl, ok := result.Previous.(string); ok {
 		cfg.PreviousUrl = prevUrl
 	}
-	resultData, err := json.Marshal(result)
-	if err != nil {
-		return nil, err
-	}
-	pokeCache.Set(url, resultData)
 	var locations LocationList
 	for _, item := range result.Results {
 		locations = append(locations, LocationInfo{Name: item.Name, URL: item.URL})
@@ -69,3 +37,19 @@ func (l *LocationResult) GetLocation(url string, cfg *config.Config) (LocationLi
 
 	return locations, nil
 }
+
+func (c *Client) locationPage(ctx context.Context, url string) (LocationResult, error) {
+	if url == "" {
+		url = c.endpoint("location-area") + "?offset=0&limit=20"
+	}
+	body, err := c.fetch(ctx, c.keyForURL(url), url)
+	if err != nil {
+		return LocationResult{}, err
+	}
+	var result LocationResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return LocationResult{}, &DecodeError{Resource: "location areas", Err: err}
+	}
+	return result, nil
+}
@@ -1,12 +1,16 @@
 package pokeapi
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"poke-repl/internal/cache"
 	"poke-repl/internal/config"
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestGetLocation(t *testing.T) {
@@ -38,9 +42,10 @@ func TestGetLocation(t *testing.T) {
 		PreviousUrl: "",
 	}
 
-	locationResult := &LocationResult{}
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
 
-	locations, err := locationResult.GetLocation(server.URL, cfg)
+	locations, err := client.GetLocation(context.Background(), server.URL, cfg)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -77,7 +82,6 @@ func TestGetLocation_WithCachedData(t *testing.T) {
 		},
 	}
 	cachedDataJSON, _ := json.Marshal(cachedData)
-	pokeCache.Set("https://pokeapi.co/api/v2/location/", cachedDataJSON)
 
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		t.Errorf("HTTP request was made to the server, but cached data should have been used")
@@ -89,9 +93,11 @@ func TestGetLocation_WithCachedData(t *testing.T) {
 		PreviousUrl: "",
 	}
 
-	locationResult := &LocationResult{}
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	client.cache.Set("location-area?limit=20&offset=0", cachedDataJSON)
 
-	locations, err := locationResult.GetLocation("https://pokeapi.co/api/v2/location/", cfg)
+	locations, err := client.GetLocation(context.Background(), server.URL+"/location-area?offset=0&limit=20", cfg)
 	if err != nil {
 		t.Errorf("unexpected error: %v", err)
 	}
@@ -111,3 +117,88 @@ func TestGetLocation_WithCachedData(t *testing.T) {
 		t.Errorf("expected PreviousUrl %q, got %q", expectedPreviousUrl, cfg.PreviousUrl)
 	}
 }
+
+func TestGetLocation_DefaultURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/location-area" {
+			t.Errorf("expected path /location-area, got %s", r.URL.Path)
+		}
+		if r.URL.RawQuery != "offset=0&limit=20" {
+			t.Errorf("expected query offset=0&limit=20, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{"count": 0, "next": "", "previous": null, "results": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	_, err := client.GetLocation(context.Background(), "", &config.Config{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestGetLocation_RevalidatesExpiredEntry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		if r.Header.Get("If-Modified-Since") != "" {
+			t.Errorf("expected no If-Modified-Since without a Last-Modified header")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"count": 1, "results": [{"name": "location1"}]}`))
+	}))
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Hour, cache.WithTTL(-time.Second))
+	defer pokeCache.Close()
+	client := NewClient(WithBaseURL(server.URL), WithCache(pokeCache))
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		locations, err := client.GetLocation(context.Background(), "", &config.Config{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(locations) != 1 || locations[0].Name != "location1" {
+			t.Errorf("expected location1, got %v", locations)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 requests, got %d", calls)
+	}
+	entry, ok := client.cache.Peek("location-area?limit=20&offset=0")
+	if !ok || entry.ETag != `"v1"` {
+		t.Errorf("expected the ETag to be kept with the entry, got %+v", entry)
+	}
+}
+
+func TestGetLocation_ModifiedEntryIsReplaced(t *testing.T) {
+	version := 1
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-Modified-Since") == "" && version == 2 {
+			t.Errorf("expected a conditional request for the expired entry")
+		}
+		w.Header().Set("Last-Modified", time.Unix(int64(version), 0).UTC().Format(http.TimeFormat))
+		fmt.Fprintf(w, `{"count": 1, "results": [{"name": "location%d"}]}`, version)
+	}))
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Hour, cache.WithTTL(-time.Second))
+	defer pokeCache.Close()
+	client := NewClient(WithBaseURL(server.URL), WithCache(pokeCache))
+	defer client.Close()
+	client.GetLocation(context.Background(), "", &config.Config{})
+	version = 2
+	locations, err := client.GetLocation(context.Background(), "", &config.Config{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if locations[0].Name != "location2" {
+		t.Errorf("expected the modified body to be returned, got %v", locations)
+	}
+}
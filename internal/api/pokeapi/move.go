@@ -0,0 +1,64 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+type MoveResult struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Power         int           `json:"power"`
+	Accuracy      int           `json:"accuracy"`
+	PP            int           `json:"pp"`
+	Priority      int           `json:"priority"`
+	EffectChance  int           `json:"effect_chance"`
+	Type          NamedResource `json:"type"`
+	DamageClass   NamedResource `json:"damage_class"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    NamedResource `json:"language"`
+	} `json:"effect_entries"`
+}
+
+// ShortEffect returns the short effect text of the move in language with
+// its effect chance filled in, or an empty string when it isn't translated.
+func (m *MoveResult) ShortEffect(language string) string {
+	for _, entry := range m.EffectEntries {
+		if entry.Language.Name == language {
+			return strings.ReplaceAll(entry.ShortEffect, "$effect_chance", strconv.Itoa(m.EffectChance))
+		}
+	}
+	return ""
+}
+
+func (c *Client) Move(ctx context.Context, name string) (*MoveResult, error) {
+	key, url := c.resourceURL("move", name)
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result MoveResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "move", Err: err}
+	}
+	return &result, nil
+}
+
+// Moves fetches every move in names with bounded concurrency, keyed by name.
+// It fails with the first error met.
+func (c *Client) Moves(ctx context.Context, names []string) (map[string]*MoveResult, error) {
+	results, err := fetchAll(ctx, names, c.Move)
+	if err != nil {
+		return nil, err
+	}
+	moves := make(map[string]*MoveResult, len(names))
+	for i, name := range names {
+		moves[name] = results[i]
+	}
+	return moves, nil
+}
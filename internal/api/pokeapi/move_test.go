@@ -0,0 +1,61 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMove(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 85, "name": "thunderbolt", "power": 90, "accuracy": 100, "pp": 15, "priority": 0,
+			"effect_chance": 10, "type": {"name": "electric"}, "damage_class": {"name": "special"},
+			"effect_entries": [{"short_effect": "Has a $effect_chance% chance to paralyze the target.", "language": {"name": "en"}}]}`))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	move, err := client.Move(context.Background(), "thunderbolt")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if move.Power != 90 || move.PP != 15 || move.Type.Name != "electric" || move.DamageClass.Name != "special" {
+		t.Errorf("unexpected move %+v", move)
+	}
+	if effect := move.ShortEffect("en"); effect != "Has a 10% chance to paralyze the target." {
+		t.Errorf("unexpected effect %q", effect)
+	}
+}
+
+func TestMoves(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/move/")
+		if name == "struggle" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprintf(w, `{"name": %q}`, name)
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRateLimiter(nil))
+	defer client.Close()
+
+	names := []string{"growl", "thunder-shock", "thunderbolt", "thunder-wave", "quick-attack", "tail-whip"}
+	moves, err := client.Moves(context.Background(), names)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(moves) != len(names) || moves["thunderbolt"].Name != "thunderbolt" {
+		t.Errorf("unexpected moves %v", moves)
+	}
+
+	_, err = client.Moves(context.Background(), append(names, "struggle"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected the failing move to be reported, got %v", err)
+	}
+}
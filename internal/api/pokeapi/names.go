@@ -0,0 +1,36 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+)
+
+// nameIndexPageSize is large enough for PokeAPI to return any resource list
+// in a single page, mirrors with a lower cap are paged through.
+const nameIndexPageSize = 2000
+
+// Names lists the name of every resource of a kind, such as "pokemon". The
+// pages are cached like any other response, so the index is only downloaded
+// once.
+func (c *Client) Names(ctx context.Context, resource string) ([]string, error) {
+	url := c.endpoint(resource) + "?offset=0&limit=" + strconv.Itoa(nameIndexPageSize)
+	var names []string
+	for url != "" {
+		body, err := c.fetch(ctx, c.keyForURL(url), url)
+		if err != nil {
+			return nil, err
+		}
+		var page LocationResult
+		if err := json.Unmarshal(body, &page); err != nil {
+			return nil, &DecodeError{Resource: resource + " list", Err: err}
+		}
+		for _, item := range page.Results {
+			names = append(names, item.Name)
+		}
+		url = page.Next
+	}
+	sort.Strings(names)
+	return names, nil
+}
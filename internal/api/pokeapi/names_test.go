@@ -0,0 +1,45 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestNames(t *testing.T) {
+	calls := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Path != "/pokemon" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		// The mirror caps pages at two results regardless of the limit asked for.
+		if r.URL.Query().Get("offset") == "2" {
+			w.Write([]byte(`{"count": 3, "next": null, "results": [{"name": "bulbasaur"}]}`))
+			return
+		}
+		fmt.Fprintf(w, `{"count": 3, "next": "%s/pokemon?offset=2&limit=2", "results": [{"name": "pikachu"}, {"name": "mew"}]}`, server.URL)
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	names, err := client.Names(context.Background(), "pokemon")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{"bulbasaur", "mew", "pikachu"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("expected %v, got %v", want, names)
+	}
+
+	if _, err := client.Names(context.Background(), "pokemon"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected the index to be served from the cache, got %d calls", calls)
+	}
+}
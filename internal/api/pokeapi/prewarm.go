@@ -0,0 +1,206 @@
+package pokeapi
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+const DefaultPrewarmConcurrency = 4
+
+type PrewarmStage string
+
+const (
+	StageLocationAreas PrewarmStage = "location areas"
+	StageExplore       PrewarmStage = "explore"
+	StagePokemon       PrewarmStage = "pokemon"
+)
+
+type PrewarmProgress struct {
+	Stage  PrewarmStage
+	Done   int
+	Total  int
+	Failed int
+}
+
+type PrewarmOptions struct {
+	Concurrency int
+	Progress    func(PrewarmProgress)
+}
+
+// PrewarmResult counts the areas and pokemon still cached once the walk is
+// over, Evicted is how many entries the cache dropped to stay in budget.
+type PrewarmResult struct {
+	Pages   int
+	Areas   int
+	Pokemon int
+	Failed  int
+	Evicted int
+}
+
+// Prewarm fills the cache with every location area page, every area and
+// every pokemon encountered in them. Individual failures are counted and
+// skipped, only cancellation stops the walk early. Stale entries are
+// refreshed before Prewarm returns rather than in the background.
+func (c *Client) Prewarm(ctx context.Context, opts PrewarmOptions) (result PrewarmResult, err error) {
+	if opts.Concurrency < 1 {
+		opts.Concurrency = DefaultPrewarmConcurrency
+	}
+	progress := opts.Progress
+	if progress == nil {
+		progress = func(PrewarmProgress) {}
+	}
+	ctx = withFresh(ctx)
+	evictions := c.cache.Stats().Evictions
+	defer func() {
+		result.Evicted = c.cache.Stats().Evictions - evictions
+	}()
+
+	var areas []string
+	url := ""
+	for {
+		page, err := c.locationPage(ctx, url)
+		if err != nil {
+			return result, err
+		}
+		result.Pages++
+		for _, item := range page.Results {
+			areas = append(areas, item.Name)
+		}
+		progress(PrewarmProgress{Stage: StageLocationAreas, Done: len(areas), Total: page.Count})
+		if page.Next == "" {
+			break
+		}
+		url = page.Next
+	}
+
+	var mu sync.Mutex
+	var explored []string
+	seen := make(map[string]bool)
+	failed, err := forEach(ctx, areas, opts.Concurrency, func(ctx context.Context, area string) error {
+		pokemons, err := c.Explore(ctx, area)
+		if err != nil {
+			return err
+		}
+		mu.Lock()
+		explored = append(explored, area)
+		for _, pokemon := range pokemons {
+			seen[pokemon] = true
+		}
+		mu.Unlock()
+		return nil
+	}, func(done, failed int) {
+		progress(PrewarmProgress{Stage: StageExplore, Done: done, Total: len(areas), Failed: failed})
+	})
+	result.Areas = c.cached("location-area", explored)
+	result.Failed += failed
+	if err != nil {
+		return result, err
+	}
+
+	pokemons := make([]string, 0, len(seen))
+	for pokemon := range seen {
+		pokemons = append(pokemons, pokemon)
+	}
+	sort.Strings(pokemons)
+	var caught []string
+	failed, err = forEach(ctx, pokemons, opts.Concurrency, func(ctx context.Context, pokemon string) error {
+		if _, err := c.CatchPokemon(ctx, pokemon); err != nil {
+			return err
+		}
+		mu.Lock()
+		caught = append(caught, pokemon)
+		mu.Unlock()
+		return nil
+	}, func(done, failed int) {
+		progress(PrewarmProgress{Stage: StagePokemon, Done: done, Total: len(pokemons), Failed: failed})
+	})
+	result.Pokemon = c.cached("pokemon", caught)
+	result.Failed += failed
+	return result, err
+}
+
+// cached counts the names of resource the cache still holds.
+func (c *Client) cached(resource string, names []string) int {
+	n := 0
+	for _, name := range names {
+		key, _ := c.resourceURL(resource, name)
+		if _, ok := c.cache.Peek(key); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// forEach runs fn over items with at most concurrency calls in flight and
+// returns how many of them failed.
+func forEach(ctx context.Context, items []string, concurrency int, fn func(ctx context.Context, item string) error, progress func(done, failed int)) (int, error) {
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		done   int
+		failed int
+	)
+	work := make(chan string)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range work {
+				err := fn(ctx, item)
+				mu.Lock()
+				done++
+				if err != nil {
+					failed++
+				}
+				progress(done, failed)
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, item := range items {
+		select {
+		case work <- item:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	return failed, ctx.Err()
+}
+
+// fetchAll calls fetch for every name with bounded concurrency and returns
+// the results in the order of names, failing with the first error met.
+func fetchAll[T any](ctx context.Context, names []string, fetch func(ctx context.Context, name string) (T, error)) ([]T, error) {
+	var (
+		mu       sync.Mutex
+		results  = make(map[string]T, len(names))
+		firstErr error
+	)
+	_, err := forEach(ctx, names, DefaultPrewarmConcurrency, func(ctx context.Context, name string) error {
+		result, err := fetch(ctx, name)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			return err
+		}
+		results[name] = result
+		return nil
+	}, func(done, failed int) {})
+	if err != nil {
+		return nil, err
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	ordered := make([]T, 0, len(names))
+	for _, name := range names {
+		ordered = append(ordered, results[name])
+	}
+	return ordered, nil
+}
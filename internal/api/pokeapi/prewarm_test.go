@@ -0,0 +1,144 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"poke-repl/internal/cache"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newPrewarmServer(t *testing.T) (*httptest.Server, *sync.Map) {
+	t.Helper()
+	requests := &sync.Map{}
+	var server *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/location-area", func(w http.ResponseWriter, r *http.Request) {
+		requests.Store(r.URL.String(), true)
+		if r.URL.Query().Get("offset") == "0" {
+			fmt.Fprintf(w, `{"count": 3, "next": "%s/location-area?offset=2&limit=2", "results": [{"name": "area-1"}, {"name": "area-2"}]}`, server.URL)
+			return
+		}
+		w.Write([]byte(`{"count": 3, "next": null, "results": [{"name": "area-3"}]}`))
+	})
+	mux.HandleFunc("/location-area/{name}", func(w http.ResponseWriter, r *http.Request) {
+		requests.Store(r.URL.Path, true)
+		switch r.PathValue("name") {
+		case "area-1":
+			w.Write([]byte(`{"pokemon_encounters": [{"pokemon": {"name": "bulbasaur"}}, {"pokemon": {"name": "pidgey"}}]}`))
+		case "area-2":
+			w.Write([]byte(`{"pokemon_encounters": [{"pokemon": {"name": "pidgey"}}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	mux.HandleFunc("/pokemon/{name}", func(w http.ResponseWriter, r *http.Request) {
+		requests.Store(r.URL.Path, true)
+		fmt.Fprintf(w, `{"name": %q}`, r.PathValue("name"))
+	})
+	server = httptest.NewServer(mux)
+	return server, requests
+}
+
+func TestPrewarm(t *testing.T) {
+	server, requests := newPrewarmServer(t)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRateLimiter(nil))
+	defer client.Close()
+	var mu sync.Mutex
+	stages := make(map[PrewarmStage]PrewarmProgress)
+	result, err := client.Prewarm(context.Background(), PrewarmOptions{
+		Concurrency: 2,
+		Progress: func(p PrewarmProgress) {
+			mu.Lock()
+			defer mu.Unlock()
+			if p.Done >= stages[p.Stage].Done {
+				stages[p.Stage] = p
+			}
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := PrewarmResult{Pages: 2, Areas: 2, Pokemon: 2, Failed: 1}
+	if result != expected {
+		t.Errorf("expected %+v, got %+v", expected, result)
+	}
+	if p := stages[StageExplore]; p.Done != 3 || p.Total != 3 || p.Failed != 1 {
+		t.Errorf("unexpected final explore progress %+v", p)
+	}
+	if p := stages[StagePokemon]; p.Done != 2 || p.Total != 2 {
+		t.Errorf("unexpected final pokemon progress %+v", p)
+	}
+	for _, path := range []string{"/pokemon/bulbasaur", "/pokemon/pidgey", "/location-area/area-3"} {
+		if _, ok := requests.Load(path); !ok {
+			t.Errorf("expected a request for %s", path)
+		}
+	}
+
+	client.SetOffline(true)
+	for _, key := range []string{"location-area/area-1", "pokemon/bulbasaur", "pokemon/pidgey"} {
+		if _, ok := client.cache.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+}
+
+func TestPrewarm_Canceled(t *testing.T) {
+	server, _ := newPrewarmServer(t)
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRateLimiter(nil))
+	defer client.Close()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := client.Prewarm(ctx, PrewarmOptions{})
+	if err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestPrewarm_RefreshesStaleEntries(t *testing.T) {
+	server, _ := newPrewarmServer(t)
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Minute, cache.WithTTL(-time.Second), cache.WithHardTTL(time.Hour))
+	defer pokeCache.Close()
+	pokeCache.Set("pokemon/bulbasaur", []byte(`{"name": "stale"}`))
+	client := NewClient(WithBaseURL(server.URL), WithRateLimiter(nil), WithCache(pokeCache))
+	defer client.Close()
+
+	if _, err := client.Prewarm(context.Background(), PrewarmOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entry, _ := pokeCache.Peek("pokemon/bulbasaur")
+	if string(entry.Val) != `{"name": "bulbasaur"}` {
+		t.Errorf("expected the stale entry to be refreshed before Prewarm returns, got %s", entry.Val)
+	}
+}
+
+func TestPrewarm_ReportsEvictions(t *testing.T) {
+	server, _ := newPrewarmServer(t)
+	defer server.Close()
+
+	pokeCache := cache.NewCache(time.Minute, cache.WithMaxBytes(150))
+	defer pokeCache.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRateLimiter(nil), WithCache(pokeCache))
+	defer client.Close()
+
+	result, err := client.Prewarm(context.Background(), PrewarmOptions{Concurrency: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Evicted == 0 {
+		t.Errorf("expected evictions to be reported, got %+v", result)
+	}
+	if result.Areas >= 2 || result.Pokemon != 2 {
+		t.Errorf("expected only what is still cached to be counted, got %+v", result)
+	}
+}
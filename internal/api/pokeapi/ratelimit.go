@@ -0,0 +1,118 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRateLimit = 5
+	DefaultBurst     = 10
+)
+
+// RateLimiter is a token bucket shared by every request a Client makes.
+type RateLimiter struct {
+	mu        sync.Mutex
+	rate      float64
+	burst     int
+	tokens    float64
+	last      time.Time
+	requests  int
+	throttled int
+	totalWait time.Duration
+	now       func() time.Time
+	sleep     func(ctx context.Context, d time.Duration) error
+}
+
+type RateLimiterStats struct {
+	Rate      float64
+	Burst     int
+	Tokens    float64
+	Requests  int
+	Throttled int
+	TotalWait time.Duration
+}
+
+func NewRateLimiter(requestsPerSecond float64, burst int) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   requestsPerSecond,
+		burst:  burst,
+		tokens: float64(burst),
+		last:   time.Now(),
+		now:    time.Now,
+		sleep:  sleepContext,
+	}
+}
+
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	wait := l.reserve()
+	if wait <= 0 {
+		return nil
+	}
+	if err := l.sleep(ctx, wait); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (l *RateLimiter) Stats() RateLimiterStats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.advance()
+	return RateLimiterStats{
+		Rate:      l.rate,
+		Burst:     l.burst,
+		Tokens:    l.tokens,
+		Requests:  l.requests,
+		Throttled: l.throttled,
+		TotalWait: l.totalWait,
+	}
+}
+
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.advance()
+	l.requests++
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	wait := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.throttled++
+	l.totalWait += wait
+	return wait
+}
+
+func (l *RateLimiter) advance() {
+	now := l.now()
+	elapsed := now.Sub(l.last)
+	l.last = now
+	if elapsed <= 0 {
+		return
+	}
+	l.tokens = min(float64(l.burst), l.tokens+elapsed.Seconds()*l.rate)
+}
+
+type rateLimitTransport struct {
+	next    http.RoundTripper
+	limiter *RateLimiter
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
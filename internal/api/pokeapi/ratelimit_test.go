@@ -0,0 +1,108 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"poke-repl/internal/config"
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter(rps float64, burst int) (*RateLimiter, *time.Time, *[]time.Duration) {
+	now := time.Unix(0, 0)
+	var waits []time.Duration
+	limiter := NewRateLimiter(rps, burst)
+	limiter.last = now
+	limiter.now = func() time.Time { return now }
+	limiter.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		now = now.Add(d)
+		return nil
+	}
+	return limiter, &now, &waits
+}
+
+func TestRateLimiter_Burst(t *testing.T) {
+	limiter, _, waits := newTestRateLimiter(2, 3)
+	for i := 0; i < 3; i++ {
+		if err := limiter.Wait(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(*waits) != 0 {
+		t.Errorf("expected the burst to pass without waiting, got %v", *waits)
+	}
+
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(*waits) != 1 || (*waits)[0] != 500*time.Millisecond {
+		t.Errorf("expected a single 500ms wait, got %v", *waits)
+	}
+
+	stats := limiter.Stats()
+	if stats.Requests != 4 || stats.Throttled != 1 {
+		t.Errorf("expected 4 requests and 1 throttled, got %d and %d", stats.Requests, stats.Throttled)
+	}
+	if stats.TotalWait != 500*time.Millisecond {
+		t.Errorf("expected total wait of 500ms, got %v", stats.TotalWait)
+	}
+}
+
+func TestRateLimiter_Refill(t *testing.T) {
+	limiter, now, _ := newTestRateLimiter(10, 5)
+	for i := 0; i < 5; i++ {
+		limiter.Wait(context.Background())
+	}
+	if tokens := limiter.Stats().Tokens; tokens != 0 {
+		t.Errorf("expected an empty bucket, got %v tokens", tokens)
+	}
+
+	*now = now.Add(200 * time.Millisecond)
+	if tokens := limiter.Stats().Tokens; tokens != 2 {
+		t.Errorf("expected 2 tokens after 200ms, got %v", tokens)
+	}
+
+	*now = now.Add(time.Hour)
+	if tokens := limiter.Stats().Tokens; tokens != 5 {
+		t.Errorf("expected the bucket to be capped at the burst, got %v tokens", tokens)
+	}
+}
+
+func TestRateLimiter_CanceledWaitReturnsToken(t *testing.T) {
+	limiter := NewRateLimiter(0.001, 1)
+	limiter.Wait(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter.tokens = 0
+	if err := limiter.Wait(ctx); err == nil {
+		t.Fatalf("expected an error for a canceled context")
+	}
+	if tokens := limiter.Stats().Tokens; tokens < 0 {
+		t.Errorf("expected no tokens to be consumed, got %v", tokens)
+	}
+}
+
+func TestClient_RateLimiterSharedAcrossEndpoints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "thing", "results": []}`))
+	}))
+	defer server.Close()
+
+	limiter, _, _ := newTestRateLimiter(1, 1)
+	client := NewClient(WithBaseURL(server.URL), WithRateLimiter(limiter))
+	defer client.Close()
+	client.CatchPokemon(context.Background(), "bulbasaur")
+	client.Explore(context.Background(), "area")
+	client.GetLocation(context.Background(), "", &config.Config{})
+
+	stats := client.RateLimiter().Stats()
+	if stats.Requests != 3 {
+		t.Errorf("expected 3 requests through the limiter, got %d", stats.Requests)
+	}
+	if stats.Throttled != 2 {
+		t.Errorf("expected 2 throttled requests, got %d", stats.Throttled)
+	}
+}
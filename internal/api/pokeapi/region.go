@@ -0,0 +1,85 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+type RegionResult struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	MainGeneration NamedResource   `json:"main_generation"`
+	Locations      []NamedResource `json:"locations"`
+	Pokedexes      []NamedResource `json:"pokedexes"`
+	VersionGroups  []NamedResource `json:"version_groups"`
+	Names          []struct {
+		Name     string        `json:"name"`
+		Language NamedResource `json:"language"`
+	} `json:"names"`
+}
+
+// LocationDetailResult is a single location, such as a town or a route, and
+// the areas it is split into. LocationResult is the page of location areas
+// listed by GetLocation.
+type LocationDetailResult struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Region NamedResource   `json:"region"`
+	Areas  []NamedResource `json:"areas"`
+	Names  []struct {
+		Name     string        `json:"name"`
+		Language NamedResource `json:"language"`
+	} `json:"names"`
+}
+
+func (c *Client) Region(ctx context.Context, name string) (*RegionResult, error) {
+	key, url := c.resourceURL("region", name)
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result RegionResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "region", Err: err}
+	}
+	return &result, nil
+}
+
+func (c *Client) Location(ctx context.Context, name string) (*LocationDetailResult, error) {
+	key, url := c.resourceURL("location", name)
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result LocationDetailResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "location", Err: err}
+	}
+	return &result, nil
+}
+
+// RegionAreas lists the location areas of a region, in the order of the
+// region's locations. Every location is fetched, with bounded concurrency.
+func (c *Client) RegionAreas(ctx context.Context, region string) ([]string, error) {
+	result, err := c.Region(ctx, region)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(result.Locations))
+	for _, location := range result.Locations {
+		names = append(names, location.Name)
+	}
+	locations, err := fetchAll(ctx, names, c.Location)
+	if err != nil {
+		return nil, err
+	}
+	var areas []string
+	for _, location := range locations {
+		for _, area := range location.Areas {
+			areas = append(areas, area.Name)
+		}
+	}
+	return areas, nil
+}
@@ -0,0 +1,69 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func regionServer() *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/region/sinnoh", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 4, "name": "sinnoh", "main_generation": {"name": "generation-iv"},
+			"locations": [{"name": "canalave-city"}, {"name": "eterna-forest"}, {"name": "sinnoh-route-201"}]}`))
+	})
+	mux.HandleFunc("/location/canalave-city", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 1, "name": "canalave-city", "region": {"name": "sinnoh"}, "areas": [{"name": "canalave-city-area"}]}`))
+	})
+	mux.HandleFunc("/location/eterna-forest", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 2, "name": "eterna-forest", "region": {"name": "sinnoh"}, "areas": [{"name": "eterna-forest-area"}]}`))
+	})
+	mux.HandleFunc("/location/sinnoh-route-201", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": 3, "name": "sinnoh-route-201", "region": {"name": "sinnoh"}, "areas": [{"name": "sinnoh-route-201-area"}, {"name": "sinnoh-route-201-lake"}]}`))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestRegionAndLocation(t *testing.T) {
+	server := regionServer()
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	region, err := client.Region(context.Background(), "Sinnoh")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if region.MainGeneration.Name != "generation-iv" || len(region.Locations) != 3 {
+		t.Errorf("unexpected region %+v", region)
+	}
+	location, err := client.Location(context.Background(), "canalave-city")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if location.Region.Name != "sinnoh" || len(location.Areas) != 1 || location.Areas[0].Name != "canalave-city-area" {
+		t.Errorf("unexpected location %+v", location)
+	}
+	if _, err := client.Location(context.Background(), "pallet-town"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+}
+
+func TestRegionAreas(t *testing.T) {
+	server := regionServer()
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	areas, err := client.RegionAreas(context.Background(), "sinnoh")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"canalave-city-area", "eterna-forest-area", "sinnoh-route-201-area", "sinnoh-route-201-lake"}
+	if !reflect.DeepEqual(areas, want) {
+		t.Errorf("expected %v, got %v", want, areas)
+	}
+}
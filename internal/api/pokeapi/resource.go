@@ -0,0 +1,8 @@
+package pokeapi
+
+// NamedResource is a reference to another resource the way PokeAPI embeds
+// them, such as the types a type deals double damage to.
+type NamedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
@@ -0,0 +1,192 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   250 * time.Millisecond,
+	MaxDelay:    10 * time.Second,
+}
+
+// RetryError is returned once every attempt for a request has failed.
+type RetryError struct {
+	URL        string
+	Attempts   int
+	StatusCode int
+	Err        error
+}
+
+func (e *RetryError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("error fetching %s after %d attempts: %v", e.URL, e.Attempts, e.Err)
+	}
+	return fmt.Sprintf("error fetching %s after %d attempts: %d %s", e.URL, e.Attempts, e.StatusCode, http.StatusText(e.StatusCode))
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+type retryTransport struct {
+	next   http.RoundTripper
+	policy RetryPolicy
+	sleep  func(ctx context.Context, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper, policy RetryPolicy) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{
+		next:   next,
+		policy: policy,
+		sleep:  sleepContext,
+	}
+}
+
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return t.next.RoundTrip(req)
+	}
+	for attempt := 1; ; attempt++ {
+		res, err := t.next.RoundTrip(req)
+		if err == nil && !retryableStatus(res.StatusCode) {
+			return res, nil
+		}
+		if req.Context().Err() != nil {
+			if res != nil {
+				res.Body.Close()
+			}
+			return nil, req.Context().Err()
+		}
+
+		delay := t.backoff(attempt)
+		retryErr := &RetryError{URL: req.URL.String(), Attempts: attempt, Err: err}
+		if res != nil {
+			retryErr.StatusCode = res.StatusCode
+			if after, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+				delay = after
+			}
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		if attempt >= t.policy.MaxAttempts {
+			return nil, retryErr
+		}
+		deadline, ok := retryDeadline(req.Context())
+		if ok && time.Until(deadline) < delay {
+			return nil, retryErr
+		}
+		if !ok && delay > t.policy.MaxDelay {
+			// Without a deadline nothing else bounds a long Retry-After.
+			delay = t.policy.MaxDelay
+		}
+		if err := t.sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type retryDeadlineKey struct{}
+
+// withRetryDeadline tells the retry transport when the caller stops waiting,
+// for requests running on a context detached from the caller's.
+func withRetryDeadline(ctx context.Context, deadline time.Time) context.Context {
+	return context.WithValue(ctx, retryDeadlineKey{}, deadline)
+}
+
+func retryDeadline(ctx context.Context) (time.Time, bool) {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline, true
+	}
+	deadline, ok := ctx.Value(retryDeadlineKey{}).(time.Time)
+	return deadline, ok
+}
+
+// timeoutTransport bounds a single round trip, including reading the body,
+// so that every retry attempt gets the full timeout.
+type timeoutTransport struct {
+	next    http.RoundTripper
+	timeout time.Duration
+}
+
+func (t *timeoutTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	next := t.next
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	ctx, cancel := context.WithTimeout(req.Context(), t.timeout)
+	res, err := next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	res.Body = &cancelBody{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+// cancelBody releases the attempt's context once the body is closed.
+type cancelBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+func (t *retryTransport) backoff(attempt int) time.Duration {
+	delay := t.policy.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > t.policy.MaxDelay {
+		delay = t.policy.MaxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)))
+}
+
+func retryableStatus(code int) bool {
+	return code == http.StatusTooManyRequests || code >= http.StatusInternalServerError
+}
+
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
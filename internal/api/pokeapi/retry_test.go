@@ -0,0 +1,247 @@
+package pokeapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestRetryTransport(policy RetryPolicy) (*retryTransport, *[]time.Duration) {
+	var delays []time.Duration
+	transport := newRetryTransport(nil, policy)
+	transport.sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	return transport, &delays
+}
+
+func TestRetryTransport_RetriesUntilSuccess(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "bulbasaur"}`))
+	}))
+	defer server.Close()
+
+	transport, delays := newTestRetryTransport(RetryPolicy{MaxAttempts: 4, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second})
+	client := NewClient(WithBaseURL(server.URL), WithHTTPClient(&http.Client{Transport: transport}), WithRetryPolicy(RetryPolicy{}))
+	defer client.Close()
+
+	result, err := client.CatchPokemon(context.Background(), "bulbasaur")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Name != "bulbasaur" {
+		t.Errorf("expected name bulbasaur, got %s", result.Name)
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 calls, got %d", calls)
+	}
+	if len(*delays) != 2 {
+		t.Fatalf("expected 2 backoff delays, got %d", len(*delays))
+	}
+	for i, d := range *delays {
+		ceiling := 100 * time.Millisecond << i
+		if d < ceiling/2 || d > ceiling {
+			t.Errorf("expected delay %d to be between %v and %v, got %v", i, ceiling/2, ceiling, d)
+		}
+	}
+}
+
+func TestRetryTransport_HonorsRetryAfter(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	transport, delays := newTestRetryTransport(DefaultRetryPolicy)
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if len(*delays) != 1 || (*delays)[0] != 7*time.Second {
+		t.Errorf("expected a single 7s delay, got %v", *delays)
+	}
+}
+
+func TestRetryTransport_ClampsRetryAfterWithoutDeadline(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "3600")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	transport, delays := newTestRetryTransport(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Second})
+	req, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if len(*delays) != 1 || (*delays)[0] != time.Second {
+		t.Errorf("expected the Retry-After delay to be clamped to 1s, got %v", *delays)
+	}
+}
+
+func TestRetryTransport_ExhaustedAttempts(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	transport, _ := newTestRetryTransport(RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+	client := NewClient(WithBaseURL(server.URL), WithHTTPClient(&http.Client{Transport: transport}), WithRetryPolicy(RetryPolicy{}))
+	defer client.Close()
+
+	_, err := client.Explore(context.Background(), "area")
+	var retryErr *RetryError
+	if !errors.As(err, &retryErr) {
+		t.Fatalf("expected a RetryError, got %v", err)
+	}
+	if retryErr.Attempts != 3 || calls != 3 {
+		t.Errorf("expected 3 attempts, got %d (server saw %d)", retryErr.Attempts, calls)
+	}
+	if retryErr.StatusCode != http.StatusBadGateway {
+		t.Errorf("expected status %d, got %d", http.StatusBadGateway, retryErr.StatusCode)
+	}
+}
+
+func TestRetryTransport_DoesNotRetryClientErrors(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	transport, _ := newTestRetryTransport(DefaultRetryPolicy)
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	res, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	res.Body.Close()
+	if calls != 1 {
+		t.Errorf("expected a single call, got %d", calls)
+	}
+}
+
+func TestClient_RetryAfterLongerThanTimeout(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "bulbasaur"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithTimeout(500*time.Millisecond), WithRateLimiter(nil))
+	defer client.Close()
+	if _, err := client.CatchPokemon(context.Background(), "bulbasaur"); err != nil {
+		t.Fatalf("expected the retry after the Retry-After delay to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestClient_RetryAfterPastDeadline(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithTimeout(time.Second), WithRateLimiter(nil))
+	defer client.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	_, err := client.CatchPokemon(ctx, "bulbasaur")
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("expected ErrRateLimited, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("expected a single call, got %d", calls)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("expected to give up without waiting, took %v", elapsed)
+	}
+}
+
+func TestClient_TimeoutPerAttempt(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"id": 1, "name": "bulbasaur"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithTimeout(50*time.Millisecond),
+		WithRetryPolicy(RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}),
+		WithRateLimiter(nil),
+	)
+	defer client.Close()
+	if _, err := client.CatchPokemon(context.Background(), "bulbasaur"); err != nil {
+		t.Fatalf("expected the second attempt to succeed, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{value: "", ok: false},
+		{value: "3", want: 3 * time.Second, ok: true},
+		{value: "soon", ok: false},
+		{value: time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), want: 0, ok: true},
+	}
+	for _, c := range cases {
+		got, ok := retryAfter(c.value)
+		if ok != c.ok || got != c.want {
+			t.Errorf("retryAfter(%q) = %v, %v; want %v, %v", c.value, got, ok, c.want, c.ok)
+		}
+	}
+}
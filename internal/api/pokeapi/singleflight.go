@@ -0,0 +1,72 @@
+package pokeapi
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+type flight struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// flightGroup deduplicates concurrent fetches of the same resource so only
+// one request reaches PokeAPI.
+type flightGroup struct {
+	mu      sync.Mutex
+	flights map[string]*flight
+}
+
+// do runs fn once per key at a time. fn runs detached from the caller's
+// cancellation so one impatient caller can't fail the others, each caller
+// still stops waiting when its own context is done. The first caller's
+// deadline is kept as a hint so retries don't wait past it.
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.flights == nil {
+		g.flights = make(map[string]*flight)
+	}
+	f, ok := g.flights[key]
+	if !ok {
+		f = &flight{done: make(chan struct{})}
+		g.flights[key] = f
+		detached := context.WithoutCancel(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			detached = withRetryDeadline(detached, deadline)
+		}
+		go func() {
+			f.body, f.err = fn(detached)
+			g.mu.Lock()
+			delete(g.flights, key)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-f.done:
+		return f.body, f.err
+	}
+}
+
+func canonicalURL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return raw
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+	}
+	u.RawPath = ""
+	u.RawQuery = u.Query().Encode()
+	u.Fragment = ""
+	return u.String()
+}
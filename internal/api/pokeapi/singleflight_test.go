@@ -0,0 +1,113 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"poke-repl/internal/config"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFetch_CoalescesConcurrentRequests(t *testing.T) {
+	const callers = 10
+	var calls atomic.Int32
+	arrived := make(chan struct{}, callers)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		arrived <- struct{}{}
+		<-release
+		w.Write([]byte(`{"count": 1, "results": [{"name": "location1"}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	url := server.URL + "/location-area?offset=0&limit=20"
+
+	var wg sync.WaitGroup
+	errs := make(chan error, callers)
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			locations, err := client.GetLocation(context.Background(), url, &config.Config{})
+			if err == nil && len(locations) != 1 {
+				t.Errorf("expected 1 location, got %d", len(locations))
+			}
+			errs <- err
+		}()
+	}
+	// Callers joining after the response landed are served from the cache,
+	// either way a single request reaches the server.
+	<-arrived
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected 1 upstream call, got %d", n)
+	}
+}
+
+func TestFetch_CanceledCallerDoesNotFailOthers(t *testing.T) {
+	var calls atomic.Int32
+	arrived := make(chan struct{}, 2)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		arrived <- struct{}{}
+		<-release
+		w.Write([]byte(`{"id": 1, "name": "bulbasaur"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+	key, url := client.resourceURL("pokemon", "bulbasaur")
+
+	impatient, cancel := context.WithCancel(context.Background())
+	impatientErr := make(chan error, 1)
+	go func() {
+		_, err := client.fetch(impatient, key, url)
+		impatientErr <- err
+	}()
+	<-arrived
+
+	cancel()
+	if err := <-impatientErr; err != context.Canceled {
+		t.Errorf("expected the canceled caller to get context.Canceled, got %v", err)
+	}
+	patientBody := make(chan []byte, 1)
+	go func() {
+		body, _ := client.fetch(context.Background(), key, url)
+		patientBody <- body
+	}()
+	close(release)
+	if body := <-patientBody; len(body) == 0 {
+		t.Errorf("expected the remaining caller to get the response")
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("expected the request to outlive the canceled caller, got %d upstream calls", n)
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	cases := map[string]string{
+		"HTTPS://PokeAPI.co/api/v2/pokemon/pikachu/":                "https://pokeapi.co/api/v2/pokemon/pikachu",
+		"https://pokeapi.co/api/v2/location-area?limit=20&offset=0": "https://pokeapi.co/api/v2/location-area?limit=20&offset=0",
+		"https://pokeapi.co/api/v2/location-area?offset=0&limit=20": "https://pokeapi.co/api/v2/location-area?limit=20&offset=0",
+	}
+	for in, want := range cases {
+		if got := canonicalURL(in); got != want {
+			t.Errorf("canonicalURL(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+)
+
+// DefaultLanguage is the language used for localized text when none is
+// chosen.
+const DefaultLanguage = "en"
+
+type SpeciesResult struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Order         int    `json:"order"`
+	GenderRate    int    `json:"gender_rate"`
+	CaptureRate   int    `json:"capture_rate"`
+	BaseHappiness int    `json:"base_happiness"`
+	HatchCounter  int    `json:"hatch_counter"`
+	IsBaby        bool   `json:"is_baby"`
+	IsLegendary   bool   `json:"is_legendary"`
+	IsMythical    bool   `json:"is_mythical"`
+	GrowthRate    struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"growth_rate"`
+	Habitat struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"habitat"`
+	EvolutionChain struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Genera []struct {
+		Genus    string `json:"genus"`
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+	} `json:"genera"`
+	FlavorTextEntries []struct {
+		FlavorText string `json:"flavor_text"`
+		Language   struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+		Version struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"version"`
+	} `json:"flavor_text_entries"`
+	Names []struct {
+		Name     string `json:"name"`
+		Language struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+		} `json:"language"`
+	} `json:"names"`
+}
+
+// Genus returns the genus of the species in language, such as "Mouse
+// Pokémon", or an empty string when it isn't translated.
+func (s *SpeciesResult) Genus(language string) string {
+	for _, genus := range s.Genera {
+		if genus.Language.Name == language {
+			return genus.Genus
+		}
+	}
+	return ""
+}
+
+// FlavorText returns the Pokédex entry of the species in language for a game
+// version along with that version. An empty version picks the entry of the
+// most recent game.
+func (s *SpeciesResult) FlavorText(language, version string) (string, string, bool) {
+	for i := len(s.FlavorTextEntries) - 1; i >= 0; i-- {
+		entry := s.FlavorTextEntries[i]
+		if entry.Language.Name != language || (version != "" && entry.Version.Name != version) {
+			continue
+		}
+		// Entries keep the line and page breaks of the game's text box.
+		return strings.Join(strings.Fields(entry.FlavorText), " "), entry.Version.Name, true
+	}
+	return "", "", false
+}
+
+func (c *Client) Species(ctx context.Context, name string) (*SpeciesResult, error) {
+	key, url := c.resourceURL("pokemon-species", name)
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result SpeciesResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "pokemon species", Err: err}
+	}
+	return &result, nil
+}
@@ -0,0 +1,74 @@
+package pokeapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const pikachuSpecies = `{
+	"id": 25, "name": "pikachu", "capture_rate": 190, "base_happiness": 50,
+	"is_legendary": false, "is_mythical": false,
+	"growth_rate": {"name": "medium"}, "habitat": {"name": "forest"},
+	"genera": [{"genus": "ねずみポケモン", "language": {"name": "ja"}}, {"genus": "Mouse Pokémon", "language": {"name": "en"}}],
+	"flavor_text_entries": [
+		{"flavor_text": "When several of\nthese POKéMON\fgather, their", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "It keeps its tail\nraised to monitor\fits surroundings.", "language": {"name": "en"}, "version": {"name": "yellow"}},
+		{"flavor_text": "ほっぺたの りょうがわに", "language": {"name": "ja"}, "version": {"name": "red"}}
+	]
+}`
+
+func TestSpecies(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/pokemon-species/pikachu" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(pikachuSpecies))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	species, err := client.Species(context.Background(), "Pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if species.CaptureRate != 190 || species.GrowthRate.Name != "medium" || species.Habitat.Name != "forest" {
+		t.Errorf("unexpected species %+v", species)
+	}
+	if genus := species.Genus("en"); genus != "Mouse Pokémon" {
+		t.Errorf("expected the english genus, got %q", genus)
+	}
+	if genus := species.Genus("fr"); genus != "" {
+		t.Errorf("expected no genus for a missing language, got %q", genus)
+	}
+}
+
+func TestSpecies_FlavorText(t *testing.T) {
+	client := NewClient()
+	defer client.Close()
+	client.cache.Set("pokemon-species/pikachu", []byte(pikachuSpecies))
+	species, err := client.Species(context.Background(), "pikachu")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		language, version string
+		text, from        string
+		ok                bool
+	}{
+		{"en", "red", "When several of these POKéMON gather, their", "red", true},
+		{"en", "", "It keeps its tail raised to monitor its surroundings.", "yellow", true},
+		{"ja", "", "ほっぺたの りょうがわに", "red", true},
+		{"ja", "yellow", "", "", false},
+		{"fr", "", "", "", false},
+	}
+	for _, tt := range tests {
+		text, from, ok := species.FlavorText(tt.language, tt.version)
+		if text != tt.text || from != tt.from || ok != tt.ok {
+			t.Errorf("FlavorText(%q, %q) = %q, %q, %v", tt.language, tt.version, text, from, ok)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package pokeapi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// TypeNames lists the 18 battle types in PokeAPI's order, the rows and
+// columns of a TypeChart.
+var TypeNames = []string{
+	"normal", "fighting", "flying", "poison", "ground", "rock",
+	"bug", "ghost", "steel", "fire", "water", "grass",
+	"electric", "psychic", "ice", "dragon", "dark", "fairy",
+}
+
+type TypeResult struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		NoDamageTo       []NamedResource `json:"no_damage_to"`
+		HalfDamageTo     []NamedResource `json:"half_damage_to"`
+		DoubleDamageTo   []NamedResource `json:"double_damage_to"`
+		NoDamageFrom     []NamedResource `json:"no_damage_from"`
+		HalfDamageFrom   []NamedResource `json:"half_damage_from"`
+		DoubleDamageFrom []NamedResource `json:"double_damage_from"`
+	} `json:"damage_relations"`
+	MoveDamageClass NamedResource `json:"move_damage_class"`
+	Pokemon         []struct {
+		Slot    int           `json:"slot"`
+		Pokemon NamedResource `json:"pokemon"`
+	} `json:"pokemon"`
+	Moves []NamedResource `json:"moves"`
+}
+
+// TypeChart holds the damage multiplier of every attacking type, the rows,
+// against every defending type, the columns.
+type TypeChart [18][18]float64
+
+// NewTypeChart builds the chart from the damage relations of types, pairs
+// no type mentions deal regular damage.
+func NewTypeChart(types []*TypeResult) *TypeChart {
+	var chart TypeChart
+	for i := range chart {
+		for j := range chart[i] {
+			chart[i][j] = 1
+		}
+	}
+	for _, t := range types {
+		attacking, ok := typeIndex(t.Name)
+		if !ok {
+			continue
+		}
+		relations := t.DamageRelations
+		for _, multiplier := range []struct {
+			value float64
+			types []NamedResource
+		}{
+			{2, relations.DoubleDamageTo},
+			{0.5, relations.HalfDamageTo},
+			{0, relations.NoDamageTo},
+		} {
+			for _, defending := range multiplier.types {
+				if j, ok := typeIndex(defending.Name); ok {
+					chart[attacking][j] = multiplier.value
+				}
+			}
+		}
+	}
+	return &chart
+}
+
+// Effectiveness returns the multiplier of an attack of type attacking against
+// a pokemon of every type in defending, unknown types count as regular
+// damage.
+func (c *TypeChart) Effectiveness(attacking string, defending ...string) float64 {
+	i, ok := typeIndex(attacking)
+	if !ok {
+		return 1
+	}
+	multiplier := 1.0
+	for _, name := range defending {
+		if j, ok := typeIndex(name); ok {
+			multiplier *= c[i][j]
+		}
+	}
+	return multiplier
+}
+
+// Defense returns the multiplier of every attacking type against a pokemon
+// of the defending types.
+func (c *TypeChart) Defense(defending ...string) map[string]float64 {
+	multipliers := make(map[string]float64, len(TypeNames))
+	for _, attacking := range TypeNames {
+		multipliers[attacking] = c.Effectiveness(attacking, defending...)
+	}
+	return multipliers
+}
+
+func typeIndex(name string) (int, bool) {
+	for i, typeName := range TypeNames {
+		if typeName == name {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func (c *Client) Type(ctx context.Context, name string) (*TypeResult, error) {
+	key, url := c.resourceURL("type", name)
+	body, err := c.fetch(ctx, key, url)
+	if err != nil {
+		return nil, err
+	}
+	var result TypeResult
+	err = json.Unmarshal(body, &result)
+	if err != nil {
+		return nil, &DecodeError{Resource: "type", Err: err}
+	}
+	return &result, nil
+}
+
+// TypeChart fetches the 18 battle types and builds their chart, the types
+// are cached like any other resource.
+func (c *Client) TypeChart(ctx context.Context) (*TypeChart, error) {
+	types := make([]*TypeResult, 0, len(TypeNames))
+	for _, name := range TypeNames {
+		t, err := c.Type(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, t)
+	}
+	return NewTypeChart(types), nil
+}
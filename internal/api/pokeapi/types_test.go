@@ -0,0 +1,82 @@
+package pokeapi
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// attackRelations is the part of the real type chart the tests rely on.
+var attackRelations = map[string]string{
+	"fire":     `"double_damage_to": [{"name": "grass"}, {"name": "ice"}, {"name": "bug"}, {"name": "steel"}], "half_damage_to": [{"name": "fire"}, {"name": "water"}, {"name": "rock"}, {"name": "dragon"}]`,
+	"water":    `"double_damage_to": [{"name": "fire"}, {"name": "ground"}, {"name": "rock"}], "half_damage_to": [{"name": "water"}, {"name": "grass"}, {"name": "dragon"}]`,
+	"grass":    `"double_damage_to": [{"name": "water"}, {"name": "ground"}, {"name": "rock"}], "half_damage_to": [{"name": "fire"}, {"name": "grass"}, {"name": "poison"}, {"name": "flying"}, {"name": "bug"}, {"name": "dragon"}, {"name": "steel"}]`,
+	"ground":   `"double_damage_to": [{"name": "fire"}, {"name": "electric"}, {"name": "poison"}, {"name": "rock"}, {"name": "steel"}], "half_damage_to": [{"name": "grass"}, {"name": "bug"}], "no_damage_to": [{"name": "flying"}]`,
+	"rock":     `"double_damage_to": [{"name": "fire"}, {"name": "ice"}, {"name": "flying"}, {"name": "bug"}], "half_damage_to": [{"name": "fighting"}, {"name": "ground"}, {"name": "steel"}]`,
+	"electric": `"double_damage_to": [{"name": "water"}, {"name": "flying"}], "half_damage_to": [{"name": "electric"}, {"name": "grass"}, {"name": "dragon"}], "no_damage_to": [{"name": "ground"}]`,
+}
+
+func typeServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/type/")
+		fmt.Fprintf(w, `{"name": %q, "damage_relations": {%s}}`, name, attackRelations[name])
+	}))
+}
+
+func TestType(t *testing.T) {
+	server := typeServer(t)
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL))
+	defer client.Close()
+
+	fire, err := client.Type(context.Background(), "Fire")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fire.Name != "fire" || len(fire.DamageRelations.DoubleDamageTo) != 4 {
+		t.Errorf("unexpected type %+v", fire)
+	}
+}
+
+func TestTypeChart(t *testing.T) {
+	server := typeServer(t)
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRateLimiter(nil))
+	defer client.Close()
+
+	chart, err := client.TypeChart(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		attacking string
+		defending []string
+		want      float64
+	}{
+		{"fire", []string{"grass"}, 2},
+		{"fire", []string{"water"}, 0.5},
+		{"normal", []string{"fire"}, 1},
+		{"ground", []string{"flying"}, 0},
+		{"rock", []string{"fire", "flying"}, 4},
+		{"grass", []string{"fire", "flying"}, 0.25},
+		{"ground", []string{"fire", "flying"}, 0},
+		{"water", []string{"grass", "ground"}, 1},
+		{"shadow", []string{"fire"}, 1},
+	}
+	for _, tt := range tests {
+		if got := chart.Effectiveness(tt.attacking, tt.defending...); got != tt.want {
+			t.Errorf("Effectiveness(%s, %v) = %v, want %v", tt.attacking, tt.defending, got, tt.want)
+		}
+	}
+
+	defense := chart.Defense("fire", "flying")
+	if len(defense) != 18 {
+		t.Errorf("expected a multiplier for every type, got %d", len(defense))
+	}
+	if defense["rock"] != 4 || defense["electric"] != 2 || defense["ground"] != 0 {
+		t.Errorf("unexpected defense %v", defense)
+	}
+}
@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+)
+
+const (
+	bundleVersion  = 1
+	bundleManifest = "manifest.json"
+)
+
+var ErrCorruptBundle = errors.New("corrupt cache bundle")
+
+// MergePolicy decides what happens when an imported entry is already cached.
+type MergePolicy int
+
+const (
+	MergeNewer MergePolicy = iota
+	MergeKeep
+	MergeOverwrite
+)
+
+func ParseMergePolicy(name string) (MergePolicy, error) {
+	switch name {
+	case "newer":
+		return MergeNewer, nil
+	case "keep":
+		return MergeKeep, nil
+	case "overwrite":
+		return MergeOverwrite, nil
+	}
+	return 0, fmt.Errorf("unknown merge policy %q, expected newer, keep or overwrite", name)
+}
+
+type ImportResult struct {
+	Imported int
+	Skipped  int
+}
+
+type manifest struct {
+	Version    int             `json:"version"`
+	ExportedAt time.Time       `json:"exported_at"`
+	Entries    []manifestEntry `json:"entries"`
+}
+
+type manifestEntry struct {
+	Key       string    `json:"key"`
+	File      string    `json:"file"`
+	SHA256    string    `json:"sha256"`
+	CreatedAt time.Time `json:"created_at"`
+	Validators
+}
+
+// Export writes every stored entry, expired or not, to w as a gzipped tar
+// archive holding a manifest and one file per raw value.
+func (c *PokeCache) Export(w io.Writer) (int, error) {
+	var entries []Entry
+	c.mu.Lock()
+	err := c.storage.Range(func(entry Entry) bool {
+		entries = append(entries, entry)
+		return true
+	})
+	c.mu.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	m := manifest{Version: bundleVersion, ExportedAt: c.clock.Now().UTC()}
+	for i, entry := range entries {
+		sum := sha256.Sum256(entry.Val)
+		m.Entries = append(m.Entries, manifestEntry{
+			Key:        entry.Key,
+			File:       fmt.Sprintf("entries/%06d.json", i),
+			SHA256:     hex.EncodeToString(sum[:]),
+			CreatedAt:  entry.CreatedAt,
+			Validators: entry.Validators,
+		})
+	}
+	manifestData, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	if err := writeTarFile(tw, bundleManifest, manifestData, m.ExportedAt); err != nil {
+		return 0, err
+	}
+	for i, entry := range entries {
+		if err := writeTarFile(tw, m.Entries[i].File, entry.Val, entry.CreatedAt); err != nil {
+			return 0, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return 0, err
+	}
+	if err := gz.Close(); err != nil {
+		return 0, err
+	}
+	return len(entries), nil
+}
+
+// Import verifies every checksum in the bundle before storing anything, so a
+// damaged bundle leaves the cache untouched. Imported entries keep their
+// original fetch time and expire according to this cache's TTL counted from
+// it, so an old bundle does not bring back fresh entries.
+func (c *PokeCache) Import(r io.Reader, policy MergePolicy) (ImportResult, error) {
+	m, files, err := readBundle(r)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	for _, me := range m.Entries {
+		val, ok := files[me.File]
+		if !ok {
+			return ImportResult{}, fmt.Errorf("%w: missing %s for %s", ErrCorruptBundle, me.File, me.Key)
+		}
+		sum := sha256.Sum256(val)
+		if hex.EncodeToString(sum[:]) != me.SHA256 {
+			return ImportResult{}, fmt.Errorf("%w: checksum mismatch for %s", ErrCorruptBundle, me.Key)
+		}
+	}
+
+	var result ImportResult
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, me := range m.Entries {
+		existing, ok, err := c.storage.Get(me.Key)
+		if err == nil && ok && !shouldReplace(policy, existing, me) {
+			result.Skipped++
+			continue
+		}
+		entry := Entry{
+			Key:        me.Key,
+			Val:        files[me.File],
+			CreatedAt:  me.CreatedAt,
+			Validators: me.Validators,
+		}
+		if entry.CreatedAt.IsZero() {
+			entry.CreatedAt = c.clock.Now()
+		}
+		c.setExpiry(&entry, entry.CreatedAt)
+		stored := c.store(entry)
+		if stored {
+			result.Imported++
+		} else {
+			result.Skipped++
+		}
+	}
+	return result, nil
+}
+
+func shouldReplace(policy MergePolicy, existing Entry, incoming manifestEntry) bool {
+	switch policy {
+	case MergeKeep:
+		return false
+	case MergeOverwrite:
+		return true
+	}
+	return incoming.CreatedAt.After(existing.CreatedAt)
+}
+
+func readBundle(r io.Reader) (manifest, map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return manifest{}, nil, fmt.Errorf("%w: %v", ErrCorruptBundle, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return manifest{}, nil, fmt.Errorf("%w: %v", ErrCorruptBundle, err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return manifest{}, nil, fmt.Errorf("%w: %v", ErrCorruptBundle, err)
+		}
+		files[header.Name] = data
+	}
+
+	data, ok := files[bundleManifest]
+	if !ok {
+		return manifest{}, nil, fmt.Errorf("%w: missing %s", ErrCorruptBundle, bundleManifest)
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return manifest{}, nil, fmt.Errorf("%w: %v", ErrCorruptBundle, err)
+	}
+	if m.Version != bundleVersion {
+		return manifest{}, nil, fmt.Errorf("unsupported cache bundle version %d", m.Version)
+	}
+	return m, files, nil
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	err := tw.WriteHeader(&tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = tw.Write(data)
+	return err
+}
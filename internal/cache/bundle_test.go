@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	source := NewCache(time.Minute)
+	defer source.Close()
+	source.SetWithValidators("pokemon/pikachu", []byte(`{"name": "pikachu"}`), Validators{ETag: `"v1"`})
+	source.Set("location-area/canalave-city-area", []byte(`{"name": "canalave-city-area"}`))
+
+	var buf bytes.Buffer
+	n, err := source.Export(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 2 {
+		t.Errorf("expected 2 exported entries, got %d", n)
+	}
+
+	target := NewCache(time.Minute)
+	defer target.Close()
+	result, err := target.Import(&buf, MergeNewer)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Imported != 2 || result.Skipped != 0 {
+		t.Errorf("expected 2 imported entries, got %+v", result)
+	}
+
+	original, _ := source.Lookup("pokemon/pikachu")
+	imported, ok := target.Lookup("pokemon/pikachu")
+	if !ok {
+		t.Fatalf("expected pokemon/pikachu to be imported")
+	}
+	if string(imported.Val) != `{"name": "pikachu"}` || imported.ETag != `"v1"` {
+		t.Errorf("unexpected imported entry %+v", imported)
+	}
+	if !imported.CreatedAt.Equal(original.CreatedAt) {
+		t.Errorf("expected the fetch time to be preserved, got %v want %v", imported.CreatedAt, original.CreatedAt)
+	}
+}
+
+func TestBundleImportKeepsAge(t *testing.T) {
+	clock := newFakeClock()
+	source := NewCache(time.Minute, WithClock(clock), WithTTL(time.Hour), WithHardTTL(2*time.Hour))
+	defer source.Close()
+	source.Set("pokemon/pikachu", []byte(`{"name": "pikachu"}`))
+	var buf bytes.Buffer
+	if _, err := source.Export(&buf); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	clock.Advance(90 * time.Minute)
+	target := NewCache(time.Minute, WithClock(clock), WithTTL(time.Hour), WithHardTTL(2*time.Hour))
+	defer target.Close()
+	if _, err := target.Import(&buf, MergeNewer); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entry, ok := target.Peek("pokemon/pikachu")
+	if !ok {
+		t.Fatalf("expected pokemon/pikachu to be imported")
+	}
+	if !entry.Stale(clock.Now()) {
+		t.Errorf("expected an entry fetched 90 minutes ago to be stale, expires at %v", entry.ExpiresAt)
+	}
+}
+
+func TestBundleMergePolicies(t *testing.T) {
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+
+	cases := []struct {
+		policy   MergePolicy
+		existing time.Time
+		want     string
+	}{
+		{policy: MergeNewer, existing: older, want: "bundle"},
+		{policy: MergeNewer, existing: newer.Add(time.Hour), want: "local"},
+		{policy: MergeKeep, existing: older, want: "local"},
+		{policy: MergeOverwrite, existing: newer.Add(time.Hour), want: "bundle"},
+	}
+	for _, c := range cases {
+		source := NewCache(time.Minute, WithStorage(NewMemoryStorage()))
+		defer source.Close()
+		source.storage.Set(Entry{Key: "pokemon/mew", Val: []byte("bundle"), CreatedAt: newer})
+		var buf bytes.Buffer
+		if _, err := source.Export(&buf); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		target := NewCache(time.Minute)
+		defer target.Close()
+		target.storage.Set(Entry{Key: "pokemon/mew", Val: []byte("local"), CreatedAt: c.existing})
+		if _, err := target.Import(&buf, c.policy); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if val, _ := target.Get("pokemon/mew"); string(val) != c.want {
+			t.Errorf("policy %d with local entry from %v: expected %s, got %s", c.policy, c.existing, c.want, val)
+		}
+	}
+}
+
+func TestBundleChecksumMismatch(t *testing.T) {
+	source := NewCache(time.Minute)
+	defer source.Close()
+	source.Set("pokemon/pikachu", []byte(`{"name": "pikachu"}`))
+	var buf bytes.Buffer
+	source.Export(&buf)
+
+	tampered := rewriteBundle(t, buf.Bytes(), func(name string, data []byte) []byte {
+		if strings.HasPrefix(name, "entries/") {
+			return []byte(`{"name": "raichu"}`)
+		}
+		return data
+	})
+
+	target := NewCache(time.Minute)
+	defer target.Close()
+	_, err := target.Import(bytes.NewReader(tampered), MergeOverwrite)
+	if !errors.Is(err, ErrCorruptBundle) {
+		t.Fatalf("expected ErrCorruptBundle, got %v", err)
+	}
+	if _, ok := target.Get("pokemon/pikachu"); ok {
+		t.Errorf("expected nothing to be imported from a corrupt bundle")
+	}
+}
+
+func TestBundleNotAnArchive(t *testing.T) {
+	_, err := NewCache(time.Minute).Import(strings.NewReader("not a bundle"), MergeNewer)
+	if !errors.Is(err, ErrCorruptBundle) {
+		t.Errorf("expected ErrCorruptBundle, got %v", err)
+	}
+}
+
+func TestParseMergePolicy(t *testing.T) {
+	for name, want := range map[string]MergePolicy{"newer": MergeNewer, "keep": MergeKeep, "overwrite": MergeOverwrite} {
+		got, err := ParseMergePolicy(name)
+		if err != nil || got != want {
+			t.Errorf("ParseMergePolicy(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := ParseMergePolicy("merge"); err == nil {
+		t.Errorf("expected an error for an unknown policy")
+	}
+}
+
+func rewriteBundle(t *testing.T, bundle []byte, fn func(name string, data []byte) []byte) []byte {
+	t.Helper()
+	m, files, err := readBundle(bytes.NewReader(bundle))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, data := range files {
+		writeTarFile(tw, name, fn(name, data), m.ExportedAt)
+	}
+	tw.Close()
+	gz.Close()
+	return buf.Bytes()
+}
@@ -0,0 +1,176 @@
+package cache
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const entryExt = ".json"
+
+// FileStorage keeps one file per entry, a JSON header line followed by the
+// value as it was stored. Files are written to a temporary name and renamed
+// into place, so several processes can share a directory without ever
+// reading a partially written entry.
+type FileStorage struct {
+	dir string
+}
+
+// fileHeader is the first line of an entry file.
+type fileHeader struct {
+	Key           string    `json:"key"`
+	CreatedAt     time.Time `json:"created_at"`
+	ExpiresAt     time.Time `json:"expires_at"`
+	HardExpiresAt time.Time `json:"hard_expires_at,omitempty"`
+	Validators
+}
+
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "poke-repl"), nil
+}
+
+func NewFileStorage(dir string) (*FileStorage, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileStorage{dir: dir}, nil
+}
+
+func (s *FileStorage) Dir() string {
+	return s.dir
+}
+
+func (s *FileStorage) Get(key string) (Entry, bool, error) {
+	entry, _, err := s.read(s.path(key), true)
+	if errors.Is(err, fs.ErrNotExist) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	return entry, true, nil
+}
+
+func (s *FileStorage) Set(entry Entry) error {
+	header, err := json.Marshal(fileHeader{
+		Key:           entry.Key,
+		CreatedAt:     entry.CreatedAt,
+		ExpiresAt:     entry.ExpiresAt,
+		HardExpiresAt: entry.HardExpiresAt,
+		Validators:    entry.Validators,
+	})
+	if err != nil {
+		return err
+	}
+	data := append(append(header, '\n'), entry.Val...)
+	tmp, err := os.CreateTemp(s.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path(entry.Key))
+}
+
+func (s *FileStorage) Delete(key string) error {
+	err := os.Remove(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func (s *FileStorage) Range(fn func(entry Entry) bool) error {
+	return s.rangeFiles(true, func(entry Entry, _ int64) bool {
+		return fn(entry)
+	})
+}
+
+// RangeMetadata is Range without the values, only the header of every file
+// is read and the size of the value comes from the file size.
+func (s *FileStorage) RangeMetadata(fn func(entry Entry, size int64) bool) error {
+	return s.rangeFiles(false, fn)
+}
+
+func (s *FileStorage) rangeFiles(withVal bool, fn func(entry Entry, size int64) bool) error {
+	files, err := os.ReadDir(s.dir)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), entryExt) {
+			continue
+		}
+		entry, size, err := s.read(filepath.Join(s.dir, file.Name()), withVal)
+		if err != nil {
+			// Another process may have removed the entry since ReadDir.
+			continue
+		}
+		if !fn(entry, size) {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *FileStorage) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:])+entryExt)
+}
+
+// read decodes the entry file at path along with the size of its value, the
+// value itself is only loaded when withVal is set.
+func (s *FileStorage) read(path string, withVal bool) (Entry, int64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return Entry{}, 0, err
+	}
+	defer file.Close()
+	reader := bufio.NewReader(file)
+	line, err := reader.ReadBytes('\n')
+	if err != nil {
+		return Entry{}, 0, err
+	}
+	var header fileHeader
+	if err := json.Unmarshal(bytes.TrimSpace(line), &header); err != nil {
+		return Entry{}, 0, err
+	}
+	entry := Entry{
+		Key:           header.Key,
+		CreatedAt:     header.CreatedAt,
+		ExpiresAt:     header.ExpiresAt,
+		HardExpiresAt: header.HardExpiresAt,
+		Validators:    header.Validators,
+	}
+	if withVal {
+		entry.Val, err = io.ReadAll(reader)
+		if err != nil {
+			return Entry{}, 0, err
+		}
+		return entry, int64(len(entry.Val)), nil
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return Entry{}, 0, err
+	}
+	return entry, info.Size() - int64(len(line)), nil
+}
@@ -0,0 +1,143 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestFileStorage_SurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cache := NewCache(time.Minute, WithStorage(storage))
+	defer cache.Close()
+	cache.Set("https://example.com", []byte("testdata"))
+
+	reopened, err := NewFileStorage(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	restarted := NewCache(time.Minute, WithStorage(reopened))
+	defer restarted.Close()
+	val, ok := restarted.Get("https://example.com")
+	if !ok {
+		t.Fatalf("expected to find key after restart")
+	}
+	if string(val) != "testdata" {
+		t.Errorf("expected testdata, got %s", val)
+	}
+	if got, want := restarted.Stats().Bytes, cache.Stats().Bytes; got != want {
+		t.Errorf("expected the restarted cache to index %d bytes from metadata, got %d", want, got)
+	}
+}
+
+func TestFileStorage_Expiry(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now := time.Now()
+	storage.Set(Entry{Key: "fresh", Val: []byte("a"), CreatedAt: now, ExpiresAt: now.Add(time.Hour)})
+	storage.Set(Entry{Key: "stale", Val: []byte("b"), CreatedAt: now, ExpiresAt: now.Add(-time.Second)})
+
+	cache := NewCache(time.Hour, WithStorage(storage))
+	defer cache.Close()
+	if _, ok := cache.Get("stale"); ok {
+		t.Errorf("expected expired entry to be a miss")
+	}
+
+	cache.reap(now)
+	if _, ok, _ := storage.Get("stale"); ok {
+		t.Errorf("expected expired entry to be removed from disk")
+	}
+	if _, ok, _ := storage.Get("fresh"); !ok {
+		t.Errorf("expected fresh entry to stay on disk")
+	}
+}
+
+func TestFileStorage_StoresValuesVerbatim(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	val := []byte(`{"id": 25, "name": "pikachu"}`)
+	if err := storage.Set(Entry{Key: "pokemon/pikachu", Val: val}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := os.ReadFile(storage.path("pokemon/pikachu"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !bytes.HasSuffix(data, val) {
+		t.Errorf("expected the value to be stored as is, got %s", data)
+	}
+
+	var entries []Entry
+	var sizes []int64
+	storage.RangeMetadata(func(entry Entry, size int64) bool {
+		entries = append(entries, entry)
+		sizes = append(sizes, size)
+		return true
+	})
+	if len(entries) != 1 || entries[0].Key != "pokemon/pikachu" || entries[0].Val != nil {
+		t.Errorf("expected the metadata of a single entry without its value, got %+v", entries)
+	}
+	if len(sizes) != 1 || sizes[0] != int64(len(val)) {
+		t.Errorf("expected the size of the value, got %v", sizes)
+	}
+}
+
+func TestFileStorage_Delete(t *testing.T) {
+	storage, err := NewFileStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	storage.Set(Entry{Key: "key", Val: []byte("val")})
+	if err := storage.Delete("key"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := storage.Delete("key"); err != nil {
+		t.Errorf("expected deleting a missing key to succeed, got %v", err)
+	}
+	if _, ok, _ := storage.Get("key"); ok {
+		t.Errorf("expected key to be deleted")
+	}
+}
+
+func TestFileStorage_ConcurrentProcesses(t *testing.T) {
+	dir := t.TempDir()
+	var wg sync.WaitGroup
+	for p := 0; p < 2; p++ {
+		storage, err := NewFileStorage(dir)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		cache := NewCache(time.Minute, WithStorage(storage))
+		defer cache.Close()
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				cache.Set(fmt.Sprintf("key-%d", i%5), []byte(fmt.Sprintf("value from %d", p)))
+				if val, ok := cache.Get(fmt.Sprintf("key-%d", i%5)); ok && len(val) == 0 {
+					t.Errorf("read a partially written entry")
+				}
+			}
+		}(p)
+	}
+	wg.Wait()
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(files) != 5 {
+		t.Errorf("expected 5 entries and no leftover temp files, got %d files", len(files))
+	}
+}
@@ -1,56 +1,477 @@
 package cache
 
 import (
+	"container/list"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
-type cacheEntry struct {
-	createdAt time.Time
-	val       []byte
-}
-
 type PokeCache struct {
 	interval time.Duration
-	cache    map[string]cacheEntry
-	mu       sync.RWMutex
+	ttl      time.Duration
+	hardTTL  time.Duration
+	maxBytes int64
+	storage  Storage
+	clock    Clock
+
+	closeOnce sync.Once
+	done      chan struct{}
+	stopped   chan struct{}
+
+	mu         sync.Mutex
+	lru        *list.List
+	items      map[string]*list.Element
+	bytes      int64
+	expired    int
+	namespaces map[string]*NamespaceStats
+}
+
+type lruItem struct {
+	key  string
+	size int64
+}
+
+type Stats struct {
+	Entries    int
+	Bytes      int64
+	MaxBytes   int64
+	TTL        time.Duration
+	HardTTL    time.Duration
+	Hits       int
+	Misses     int
+	Evictions  int
+	Expired    int
+	Namespaces map[string]NamespaceStats
+}
+
+// NamespaceStats are the counters of the keys sharing a namespace, the part
+// of the key before the first "/" or "?", such as "pokemon".
+type NamespaceStats struct {
+	Entries   int
+	Bytes     int64
+	Hits      int
+	Misses    int
+	Evictions int
+}
+
+type Option func(*PokeCache)
+
+func WithStorage(storage Storage) Option {
+	return func(c *PokeCache) {
+		c.storage = storage
+	}
+}
+
+// WithTTL sets how long entries live, it defaults to the reap interval.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *PokeCache) {
+		c.ttl = ttl
+	}
 }
 
-func NewCache(interval time.Duration) *PokeCache {
+// WithHardTTL lets entries be served stale between their TTL and the hard
+// TTL, both counted from when the entry was stored. A hard TTL not longer
+// than the TTL disables stale serving.
+func WithHardTTL(hardTTL time.Duration) Option {
+	return func(c *PokeCache) {
+		c.hardTTL = hardTTL
+	}
+}
+
+// WithMaxBytes bounds the size of keys and values held by the cache, evicting
+// the least recently used entries first. Zero means unbounded.
+func WithMaxBytes(maxBytes int64) Option {
+	return func(c *PokeCache) {
+		c.maxBytes = maxBytes
+	}
+}
+
+func WithClock(clock Clock) Option {
+	return func(c *PokeCache) {
+		c.clock = clock
+	}
+}
+
+// NewCache starts a goroutine reaping expired entries every interval, Close
+// stops it.
+func NewCache(interval time.Duration, opts ...Option) *PokeCache {
 	cache := &PokeCache{
-		cache:    make(map[string]cacheEntry),
-		interval: interval,
+		interval:   interval,
+		ttl:        interval,
+		lru:        list.New(),
+		items:      make(map[string]*list.Element),
+		namespaces: make(map[string]*NamespaceStats),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
 	}
-	go cache.reapLoop()
+	for _, opt := range opts {
+		opt(cache)
+	}
+	if cache.storage == nil {
+		cache.storage = NewMemoryStorage()
+	}
+	if cache.clock == nil {
+		cache.clock = realClock{}
+	}
+	cache.load()
+	go cache.reapLoop(cache.clock.NewTicker(interval))
 	return cache
 }
+
+// Close stops the reaper and waits for it to exit. The cache stays usable,
+// expired entries are just no longer removed in the background.
+func (c *PokeCache) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+	})
+	<-c.stopped
+	return nil
+}
+
+func (c *PokeCache) Now() time.Time {
+	return c.clock.Now()
+}
+
 func (c *PokeCache) Get(key string) ([]byte, bool) {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
-	entry, ok := c.cache[key]
-	return entry.val, ok
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.lookup(key)
+	if !ok || entry.Expired(c.clock.Now()) {
+		return nil, false
+	}
+	return entry.Val, true
+}
+
+// Lookup returns the entry stored under key even if it has expired, so the
+// caller can revalidate it. Fresh and stale entries count as hits.
+func (c *PokeCache) Lookup(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lookup(key)
+}
+
+// Peek returns the entry stored under key without touching the hit counters
+// or the LRU order.
+func (c *PokeCache) Peek(key string) (Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok, err := c.storage.Get(key)
+	if err != nil || !ok {
+		return Entry{}, false
+	}
+	return entry, true
+}
+
+func (c *PokeCache) lookup(key string) (Entry, bool) {
+	counters := c.namespace(key)
+	entry, ok, err := c.storage.Get(key)
+	if err != nil || !ok {
+		c.untrack(key)
+		counters.Misses++
+		return Entry{}, false
+	}
+	now := c.clock.Now()
+	if entry.Expired(now) && !entry.Stale(now) {
+		counters.Misses++
+	} else {
+		counters.Hits++
+	}
+	c.track(key, entrySize(entry))
+	return entry, true
 }
 
 func (c *PokeCache) Set(key string, val []byte) {
+	c.SetWithValidators(key, val, Validators{})
+}
+
+func (c *PokeCache) SetWithValidators(key string, val []byte, validators Validators) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := c.clock.Now()
+	entry := Entry{
+		Key:        key,
+		Val:        val,
+		CreatedAt:  now,
+		Validators: validators,
+	}
+	c.setExpiry(&entry, now)
+	c.store(entry)
+}
+
+func (c *PokeCache) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok, err := c.storage.Get(key)
+	if err != nil || !ok {
+		return false
+	}
+	if err := c.storage.Delete(key); err != nil {
+		return false
+	}
+	c.untrack(key)
+	return true
+}
+
+// Purge deletes every entry whose key starts with prefix and returns how many
+// were removed, an empty prefix clears the cache.
+func (c *PokeCache) Purge(prefix string) int {
 	c.mu.Lock()
 	defer c.mu.Unlock()
-	c.cache[key] = cacheEntry{
-		createdAt: time.Now(),
-		val:       val,
+	var keys []string
+	c.rangeMetadata(func(entry Entry, _ int64) bool {
+		if strings.HasPrefix(entry.Key, prefix) {
+			keys = append(keys, entry.Key)
+		}
+		return true
+	})
+	purged := 0
+	for _, key := range keys {
+		if c.storage.Delete(key) == nil {
+			c.untrack(key)
+			purged++
+		}
 	}
+	return purged
 }
 
-func (c *PokeCache) reapLoop() {
-	ticker := time.NewTicker(c.interval)
+func (c *PokeCache) TTL() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.ttl
+}
+
+// SetTTL changes how long entries stored from now on live.
+func (c *PokeCache) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+}
+
+// Refresh extends the expiry of an entry the upstream confirmed is unchanged.
+func (c *PokeCache) Refresh(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok, err := c.storage.Get(key)
+	if err != nil || !ok {
+		return false
+	}
+	c.setExpiry(&entry, c.clock.Now())
+	if err := c.storage.Set(entry); err != nil {
+		return false
+	}
+	c.track(key, entrySize(entry))
+	return true
+}
+
+// Keys lists the keys of every stored entry starting with prefix, including
+// expired entries that have not been reaped yet.
+func (c *PokeCache) Keys(prefix string) []string {
+	var keys []string
+	c.rangeMetadata(func(entry Entry, _ int64) bool {
+		if strings.HasPrefix(entry.Key, prefix) {
+			keys = append(keys, entry.Key)
+		}
+		return true
+	})
+	sort.Strings(keys)
+	return keys
+}
+
+func (c *PokeCache) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := Stats{
+		Entries:    c.lru.Len(),
+		Bytes:      c.bytes,
+		MaxBytes:   c.maxBytes,
+		TTL:        c.ttl,
+		HardTTL:    c.hardTTL,
+		Expired:    c.expired,
+		Namespaces: make(map[string]NamespaceStats),
+	}
+	for name, counters := range c.namespaces {
+		stats.Namespaces[name] = NamespaceStats{
+			Hits:      counters.Hits,
+			Misses:    counters.Misses,
+			Evictions: counters.Evictions,
+		}
+		stats.Hits += counters.Hits
+		stats.Misses += counters.Misses
+		stats.Evictions += counters.Evictions
+	}
+	for elem := c.lru.Front(); elem != nil; elem = elem.Next() {
+		item := elem.Value.(*lruItem)
+		name := namespace(item.key)
+		ns := stats.Namespaces[name]
+		ns.Entries++
+		ns.Bytes += item.size
+		stats.Namespaces[name] = ns
+	}
+	return stats
+}
+
+func (c *PokeCache) reapLoop(ticker Ticker) {
+	defer close(c.stopped)
 	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C():
+			c.reap(c.clock.Now())
+		}
+	}
+}
+
+// reap deletes expired entries. The scan only reads entry metadata and runs
+// without c.mu, candidates are checked again under the lock since they may
+// have been refreshed in the meantime.
+func (c *PokeCache) reap(now time.Time) {
+	var expired []string
+	c.rangeMetadata(func(entry Entry, _ int64) bool {
+		if reapable(entry, now) {
+			expired = append(expired, entry.Key)
+		}
+		return true
+	})
+	if len(expired) == 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range expired {
+		entry, ok, err := c.storage.Get(key)
+		if err != nil || !ok || !reapable(entry, now) {
+			continue
+		}
+		c.storage.Delete(key)
+		c.untrack(key)
+		c.expired++
+	}
+}
+
+// reapable reports whether entry can be dropped. Entries with validators are
+// kept so they can be revalidated, the byte budget still bounds them.
+func reapable(entry Entry, now time.Time) bool {
+	return entry.Expired(now) && !entry.Stale(now) && entry.Validators.Empty()
+}
 
-	for range ticker.C {
-		c.mu.Lock()
-		for key, entry := range c.cache {
-			if time.Since(entry.createdAt) > c.interval {
-				delete(c.cache, key)
-			}
+// rangeMetadata ranges over the entries and the size of their values,
+// without the values themselves when the storage can skip reading them.
+func (c *PokeCache) rangeMetadata(fn func(entry Entry, size int64) bool) error {
+	if ranger, ok := c.storage.(MetadataRanger); ok {
+		return ranger.RangeMetadata(fn)
+	}
+	return c.storage.Range(func(entry Entry) bool {
+		return fn(entry, int64(len(entry.Val)))
+	})
+}
+
+// load indexes entries already present in the storage, oldest first, so a
+// persistent cache respects the byte budget across restarts.
+func (c *PokeCache) load() {
+	type stored struct {
+		key       string
+		size      int64
+		createdAt time.Time
+	}
+	var entries []stored
+	c.rangeMetadata(func(entry Entry, size int64) bool {
+		entries = append(entries, stored{
+			key:       entry.Key,
+			size:      int64(len(entry.Key)) + size,
+			createdAt: entry.CreatedAt,
+		})
+		return true
+	})
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].createdAt.Before(entries[j].createdAt)
+	})
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, entry := range entries {
+		c.track(entry.key, entry.size)
+	}
+	c.evict()
+}
+
+// setExpiry stamps entry with the soft and hard TTL, c.mu must be held.
+func (c *PokeCache) setExpiry(entry *Entry, now time.Time) {
+	entry.ExpiresAt = now.Add(c.ttl)
+	entry.HardExpiresAt = time.Time{}
+	if c.hardTTL > c.ttl {
+		entry.HardExpiresAt = now.Add(c.hardTTL)
+	}
+}
+
+// store writes entry and enforces the byte budget, c.mu must be held.
+func (c *PokeCache) store(entry Entry) bool {
+	size := entrySize(entry)
+	if c.maxBytes > 0 && size > c.maxBytes {
+		return false
+	}
+	if err := c.storage.Set(entry); err != nil {
+		return false
+	}
+	c.track(entry.Key, size)
+	c.evict()
+	return true
+}
+
+func (c *PokeCache) track(key string, size int64) {
+	if elem, ok := c.items[key]; ok {
+		item := elem.Value.(*lruItem)
+		c.bytes += size - item.size
+		item.size = size
+		c.lru.MoveToFront(elem)
+		return
+	}
+	c.items[key] = c.lru.PushFront(&lruItem{key: key, size: size})
+	c.bytes += size
+}
+
+func (c *PokeCache) untrack(key string) {
+	elem, ok := c.items[key]
+	if !ok {
+		return
+	}
+	c.bytes -= elem.Value.(*lruItem).size
+	c.lru.Remove(elem)
+	delete(c.items, key)
+}
+
+func (c *PokeCache) evict() {
+	for c.maxBytes > 0 && c.bytes > c.maxBytes {
+		elem := c.lru.Back()
+		if elem == nil {
+			return
 		}
-		c.mu.Unlock()
+		key := elem.Value.(*lruItem).key
+		c.storage.Delete(key)
+		c.untrack(key)
+		c.namespace(key).Evictions++
 	}
 }
+
+func (c *PokeCache) namespace(key string) *NamespaceStats {
+	name := namespace(key)
+	counters, ok := c.namespaces[name]
+	if !ok {
+		counters = &NamespaceStats{}
+		c.namespaces[name] = counters
+	}
+	return counters
+}
+
+func namespace(key string) string {
+	if i := strings.IndexAny(key, "/?"); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+func entrySize(entry Entry) int64 {
+	return int64(len(entry.Key) + len(entry.Val))
+}
@@ -2,6 +2,8 @@ package cache
 
 import (
 	"fmt"
+	"runtime"
+	"sync"
 	"testing"
 	"time"
 )
@@ -25,6 +27,7 @@ func TestSetGet(t *testing.T) {
 	for i, c := range cases {
 		t.Run(fmt.Sprintf("Test case %v", i), func(t *testing.T) {
 			cache := NewCache(interval)
+			defer cache.Close()
 			cache.Set(c.key, c.val)
 			val, ok := cache.Get(c.key)
 			if !ok {
@@ -40,9 +43,11 @@ func TestSetGet(t *testing.T) {
 }
 
 func TestReapLoop(t *testing.T) {
-	const baseTime = 5 * time.Millisecond
-	const waitTime = baseTime + 5*time.Millisecond
-	cache := NewCache(baseTime)
+	const interval = 5 * time.Millisecond
+	clock := newFakeClock()
+	storage := NewMemoryStorage()
+	cache := NewCache(interval, WithClock(clock), WithStorage(storage))
+	defer cache.Close()
 	cache.Set("https://example.com", []byte("testdata"))
 
 	_, ok := cache.Get("https://example.com")
@@ -51,11 +56,317 @@ func TestReapLoop(t *testing.T) {
 		return
 	}
 
-	time.Sleep(waitTime)
+	clock.Advance(interval + time.Millisecond)
 
 	_, ok = cache.Get("https://example.com")
 	if ok {
 		t.Errorf("expected to not find key")
 		return
 	}
+	waitFor(t, func() bool {
+		_, stored, _ := storage.Get("https://example.com")
+		return !stored
+	})
+}
+
+func TestClose(t *testing.T) {
+	clock := newFakeClock()
+	storage := NewMemoryStorage()
+	cache := NewCache(time.Second, WithClock(clock), WithStorage(storage))
+	defer cache.Close()
+	cache.Set("key", []byte("val"))
+
+	if err := cache.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := cache.Close(); err != nil {
+		t.Errorf("expected Close to be idempotent, got %v", err)
+	}
+	if clock.tickers() != 0 {
+		t.Errorf("expected the reaper ticker to be stopped")
+	}
+
+	clock.Advance(2 * time.Second)
+	if _, ok, _ := storage.Get("key"); !ok {
+		t.Errorf("expected no reaping after Close")
+	}
+	if _, ok := cache.Get("key"); ok {
+		t.Errorf("expected expiry to still be enforced after Close")
+	}
+}
+
+type fakeClock struct {
+	mu     sync.Mutex
+	now    time.Time
+	active []*fakeTicker
+}
+
+type fakeTicker struct {
+	clock  *fakeClock
+	period time.Duration
+	next   time.Time
+	c      chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Unix(1700000000, 0)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) NewTicker(d time.Duration) Ticker {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ticker := &fakeTicker{clock: c, period: d, next: c.now.Add(d), c: make(chan time.Time, 1)}
+	c.active = append(c.active, ticker)
+	return ticker
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, ticker := range c.active {
+		if c.now.Before(ticker.next) {
+			continue
+		}
+		ticker.next = c.now.Add(ticker.period)
+		select {
+		case ticker.c <- c.now:
+		default:
+		}
+	}
+}
+
+func (c *fakeClock) tickers() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.active)
+}
+
+func (t *fakeTicker) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTicker) Stop() {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	for i, ticker := range t.clock.active {
+		if ticker == t {
+			t.clock.active = append(t.clock.active[:i], t.clock.active[i+1:]...)
+			return
+		}
+	}
+}
+
+// waitFor polls cond until the reaper goroutine has caught up with the fake
+// clock.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	for i := 0; i < 1000; i++ {
+		if cond() {
+			return
+		}
+		runtime.Gosched()
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("condition not met")
+}
+
+func TestLRUEviction(t *testing.T) {
+	cache := NewCache(time.Minute, WithMaxBytes(30))
+	defer cache.Close()
+	cache.Set("a", make([]byte, 9))
+	cache.Set("b", make([]byte, 9))
+	cache.Set("c", make([]byte, 9))
+
+	// Touch "a" so "b" becomes the least recently used entry.
+	cache.Get("a")
+	cache.Set("d", make([]byte, 9))
+
+	if _, ok := cache.Get("b"); ok {
+		t.Errorf("expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c", "d"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("expected %s to be cached", key)
+		}
+	}
+
+	stats := cache.Stats()
+	if stats.Evictions != 1 {
+		t.Errorf("expected 1 eviction, got %d", stats.Evictions)
+	}
+	if stats.Entries != 3 || stats.Bytes != 30 {
+		t.Errorf("expected 3 entries using 30 bytes, got %d entries using %d bytes", stats.Entries, stats.Bytes)
+	}
+}
+
+func TestLRUSizeAccounting(t *testing.T) {
+	cache := NewCache(time.Minute, WithMaxBytes(100))
+	defer cache.Close()
+	cache.Set("key", make([]byte, 10))
+	cache.Set("key", make([]byte, 20))
+	if bytes := cache.Stats().Bytes; bytes != 23 {
+		t.Errorf("expected overwrites to replace the entry size, got %d bytes", bytes)
+	}
+
+	cache.Set("huge", make([]byte, 200))
+	if _, ok := cache.Get("huge"); ok {
+		t.Errorf("expected an entry larger than the budget to be skipped")
+	}
+	if _, ok := cache.Get("key"); !ok {
+		t.Errorf("expected existing entries to survive an oversized set")
+	}
+}
+
+func TestLRUBudgetAcrossRestart(t *testing.T) {
+	storage := NewMemoryStorage()
+	now := time.Now()
+	for i, key := range []string{"old", "mid", "new"} {
+		storage.Set(Entry{Key: key, Val: make([]byte, 7), CreatedAt: now.Add(time.Duration(i) * time.Second)})
+	}
+
+	cache := NewCache(time.Minute, WithStorage(storage), WithMaxBytes(20))
+	defer cache.Close()
+	if _, ok := cache.Get("old"); ok {
+		t.Errorf("expected the oldest entry to be evicted on load")
+	}
+	if stats := cache.Stats(); stats.Entries != 2 || stats.Evictions != 1 {
+		t.Errorf("expected 2 entries and 1 eviction, got %d and %d", stats.Entries, stats.Evictions)
+	}
+}
+
+func TestRevalidation(t *testing.T) {
+	cache := NewCache(time.Minute, WithTTL(-time.Second))
+	defer cache.Close()
+	cache.SetWithValidators("validated", []byte("a"), Validators{ETag: `"abc"`})
+	cache.Set("plain", []byte("b"))
+
+	if _, ok := cache.Get("validated"); ok {
+		t.Errorf("expected expired entry to be a miss")
+	}
+	entry, ok := cache.Lookup("validated")
+	if !ok || entry.ETag != `"abc"` {
+		t.Fatalf("expected Lookup to return the stale entry with its validators")
+	}
+
+	cache.reap(time.Now())
+	if _, ok := cache.Lookup("validated"); !ok {
+		t.Errorf("expected entries with validators to survive reaping")
+	}
+	if _, ok := cache.Lookup("plain"); ok {
+		t.Errorf("expected entries without validators to be reaped")
+	}
+
+	cache.ttl = time.Minute
+	if !cache.Refresh("validated") {
+		t.Fatalf("expected refresh to succeed")
+	}
+	if val, ok := cache.Get("validated"); !ok || string(val) != "a" {
+		t.Errorf("expected refreshed entry to be served again")
+	}
+}
+
+func TestDeleteAndPurge(t *testing.T) {
+	cache := NewCache(time.Minute)
+	defer cache.Close()
+	cache.Set("pokemon/pikachu", []byte("a"))
+	cache.Set("pokemon/mew", []byte("b"))
+	cache.Set("location-area/canalave-city-area", []byte("c"))
+
+	if !cache.Delete("pokemon/mew") {
+		t.Errorf("expected pokemon/mew to be deleted")
+	}
+	if cache.Delete("pokemon/mew") {
+		t.Errorf("expected deleting a missing key to report false")
+	}
+
+	cache.Set("pokemon/mew", []byte("b"))
+	if purged := cache.Purge("pokemon/"); purged != 2 {
+		t.Errorf("expected 2 purged entries, got %d", purged)
+	}
+	if keys := cache.Keys(""); len(keys) != 1 || keys[0] != "location-area/canalave-city-area" {
+		t.Errorf("expected only the location area to remain, got %v", keys)
+	}
+	if stats := cache.Stats(); stats.Entries != 1 || stats.Bytes != int64(len("location-area/canalave-city-area")+1) {
+		t.Errorf("expected the size accounting to follow deletions, got %+v", stats)
+	}
+	if purged := cache.Purge(""); purged != 1 {
+		t.Errorf("expected an empty prefix to purge everything, got %d", purged)
+	}
+}
+
+func TestSetTTL(t *testing.T) {
+	cache := NewCache(time.Minute)
+	defer cache.Close()
+	cache.SetTTL(-time.Second)
+	cache.Set("key", []byte("val"))
+	if _, ok := cache.Get("key"); ok {
+		t.Errorf("expected entries stored after SetTTL to use the new TTL")
+	}
+	if cache.TTL() != -time.Second {
+		t.Errorf("expected TTL to be -1s, got %v", cache.TTL())
+	}
+}
+
+func TestNamespaceStats(t *testing.T) {
+	cache := NewCache(time.Minute, WithMaxBytes(45))
+	defer cache.Close()
+	cache.Set("pokemon/pikachu", make([]byte, 5))
+	cache.Get("pokemon/pikachu")
+	cache.Get("pokemon/missingno")
+	cache.Get("location-area?limit=20&offset=0")
+	cache.Set("location-area/canalave-city-area", make([]byte, 10))
+
+	stats := cache.Stats()
+	pokemon := stats.Namespaces["pokemon"]
+	if pokemon.Hits != 1 || pokemon.Misses != 1 || pokemon.Evictions != 1 || pokemon.Entries != 0 {
+		t.Errorf("unexpected pokemon stats %+v", pokemon)
+	}
+	areas := stats.Namespaces["location-area"]
+	if areas.Misses != 1 || areas.Entries != 1 || areas.Bytes != 42 {
+		t.Errorf("unexpected location-area stats %+v", areas)
+	}
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Evictions != 1 {
+		t.Errorf("unexpected totals %+v", stats)
+	}
+}
+
+func TestSoftAndHardTTL(t *testing.T) {
+	clock := newFakeClock()
+	storage := NewMemoryStorage()
+	cache := NewCache(time.Hour, WithClock(clock), WithStorage(storage), WithTTL(time.Minute), WithHardTTL(10*time.Minute))
+	defer cache.Close()
+	cache.Set("pokemon/pikachu", []byte("pikachu"))
+
+	clock.Advance(2 * time.Minute)
+	entry, ok := cache.Lookup("pokemon/pikachu")
+	if !ok || !entry.Expired(clock.Now()) || !entry.Stale(clock.Now()) {
+		t.Fatalf("expected a stale entry between the soft and hard TTL, got %+v", entry)
+	}
+	if _, ok := cache.Get("pokemon/pikachu"); ok {
+		t.Errorf("expected Get to only return fresh entries")
+	}
+	cache.reap(clock.Now())
+	if _, ok, _ := storage.Get("pokemon/pikachu"); !ok {
+		t.Errorf("expected stale entries to survive reaping")
+	}
+
+	clock.Advance(10 * time.Minute)
+	entry, _ = cache.Lookup("pokemon/pikachu")
+	if entry.Stale(clock.Now()) {
+		t.Errorf("expected the entry to be past its hard TTL")
+	}
+	cache.reap(clock.Now())
+	if _, ok, _ := storage.Get("pokemon/pikachu"); ok {
+		t.Errorf("expected entries past their hard TTL to be reaped")
+	}
+	if stats := cache.Stats(); stats.Hits != 2 || stats.Misses != 1 {
+		t.Errorf("expected stale lookups to count as hits, got %+v", stats)
+	}
 }
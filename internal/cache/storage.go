@@ -0,0 +1,100 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Validators are the HTTP headers used to revalidate an expired entry
+// without downloading it again.
+type Validators struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+}
+
+func (v Validators) Empty() bool {
+	return v.ETag == "" && v.LastModified == ""
+}
+
+// Entry is a cached value. Past ExpiresAt (the soft TTL) it should be
+// refreshed, until HardExpiresAt it may still be served while that happens.
+type Entry struct {
+	Key           string
+	Val           []byte
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+	HardExpiresAt time.Time
+	Validators
+}
+
+func (e Entry) Expired(now time.Time) bool {
+	return !e.ExpiresAt.IsZero() && now.After(e.ExpiresAt)
+}
+
+// Stale reports whether an expired entry is still within its hard TTL.
+func (e Entry) Stale(now time.Time) bool {
+	return e.Expired(now) && now.Before(e.HardExpiresAt)
+}
+
+// Storage is where a PokeCache keeps its entries. Implementations must be
+// safe for concurrent use.
+type Storage interface {
+	Get(key string) (Entry, bool, error)
+	Set(entry Entry) error
+	Delete(key string) error
+	Range(fn func(entry Entry) bool) error
+}
+
+// MetadataRanger is implemented by storages that can list their entries
+// without reading the values, which Range would have to load. fn gets the
+// size of the value in place of the value.
+type MetadataRanger interface {
+	RangeMetadata(fn func(entry Entry, size int64) bool) error
+}
+
+type MemoryStorage struct {
+	entries map[string]Entry
+	mu      sync.RWMutex
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		entries: make(map[string]Entry),
+	}
+}
+
+func (s *MemoryStorage) Get(key string) (Entry, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[key]
+	return entry, ok, nil
+}
+
+func (s *MemoryStorage) Set(entry Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[entry.Key] = entry
+	return nil
+}
+
+func (s *MemoryStorage) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, key)
+	return nil
+}
+
+func (s *MemoryStorage) Range(fn func(entry Entry) bool) error {
+	s.mu.RLock()
+	entries := make([]Entry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	s.mu.RUnlock()
+	for _, entry := range entries {
+		if !fn(entry) {
+			break
+		}
+	}
+	return nil
+}
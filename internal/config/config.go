@@ -5,4 +5,8 @@ type Config struct {
 	NextUrl     string
 	Cmd         string
 	Referrer    string
+	// Language and Version select localized text, such as Pokédex entries.
+	// An empty Version means the most recent game.
+	Language string
+	Version  string
 }
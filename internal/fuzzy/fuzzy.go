@@ -0,0 +1,67 @@
+// Package fuzzy matches misspelled names against a list of known ones.
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+)
+
+// Distance returns the number of single rune insertions, deletions,
+// substitutions and adjacent transpositions needed to turn a into b.
+func Distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	// rows[i][j] is the distance between ra[:i] and rb[:j], only the last
+	// three rows are needed to account for transpositions.
+	rows := [3][]int{make([]int, len(rb)+1), make([]int, len(rb)+1), make([]int, len(rb)+1)}
+	for j := range rows[0] {
+		rows[0][j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		prev2, prev, cur := rows[(i+1)%3], rows[(i-1)%3], rows[i%3]
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				cur[j] = min(cur[j], prev2[j-2]+1)
+			}
+		}
+	}
+	return rows[len(ra)%3][len(rb)]
+}
+
+// Closest returns up to n candidates close enough to target to be a likely
+// typo, closest first and alphabetically among equals. Matching ignores case
+// and allows one edit for every three runes of target.
+func Closest(target string, candidates []string, n int) []string {
+	target = strings.ToLower(target)
+	maxDistance := max(len([]rune(target))/3, 1)
+	type match struct {
+		name     string
+		distance int
+	}
+	var matches []match
+	for _, candidate := range candidates {
+		d := Distance(target, strings.ToLower(candidate))
+		if d > 0 && d <= maxDistance {
+			matches = append(matches, match{candidate, d})
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].distance != matches[j].distance {
+			return matches[i].distance < matches[j].distance
+		}
+		return matches[i].name < matches[j].name
+	})
+	var names []string
+	for _, m := range matches {
+		if len(names) == n {
+			break
+		}
+		names = append(names, m.name)
+	}
+	return names
+}
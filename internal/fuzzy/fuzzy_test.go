@@ -0,0 +1,53 @@
+package fuzzy
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "mew", 3},
+		{"mew", "mew", 0},
+		{"pikachuu", "pikachu", 1},
+		{"hepl", "help", 1},
+		{"bulbasaur", "bulbsaur", 1},
+		{"charmander", "charmeleon", 5},
+		{"flabébé", "flabebe", 2},
+	}
+	for _, tt := range tests {
+		if got := Distance(tt.a, tt.b); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+		if got := Distance(tt.b, tt.a); got != tt.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", tt.b, tt.a, got, tt.want)
+		}
+	}
+}
+
+func TestClosest(t *testing.T) {
+	names := []string{"pichu", "pikachu", "raichu", "pikipek", "mew", "mewtwo"}
+	tests := []struct {
+		target string
+		n      int
+		want   []string
+	}{
+		{"pikachuu", 3, []string{"pikachu"}},
+		{"Pikachuu", 3, []string{"pikachu"}},
+		{"mewtw", 3, []string{"mewtwo"}},
+		{"pikahu", 3, []string{"pikachu", "pichu"}},
+		{"pikahu", 1, []string{"pikachu"}},
+		{"bulbasaur", 3, nil},
+		// An exact match is not a suggestion.
+		{"mew", 3, nil},
+	}
+	for _, tt := range tests {
+		if got := Closest(tt.target, names, tt.n); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Closest(%q, %d) = %v, want %v", tt.target, tt.n, got, tt.want)
+		}
+	}
+}